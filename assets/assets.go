@@ -0,0 +1,186 @@
+package assets
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"embed"
+	"encoding/hex"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Global assets configuration defaults.
+// We're providing them to make it easier to configure assets across the project,
+// just like templates configuration.
+var (
+	ASSETS_DIR               = "static"   // Assets directory, on disk or inside of embedded filesystem
+	ASSETS_PREFIX            = "/static/" // Assets URL prefix
+	ASSETS_EMBEDFS *embed.FS = nil        // Embedded filesystem, disk is used if nil
+)
+
+// hashLength is a fingerprint length in URLs.
+const hashLength = 10
+
+// Assets serves static files with content fingerprinting.
+// Fingerprinted URLs (f.e. /static/app.3f2a9c1d0b.css) are served
+// with long-lived immutable cache headers.
+type Assets struct {
+	FS     fs.FS  // Assets filesystem
+	Prefix string // URL prefix
+
+	mu     sync.Mutex
+	hashes map[string]fingerprint
+}
+
+// fingerprint is a cached file content hash.
+type fingerprint struct {
+	modified time.Time
+	size     int64
+	hash     string
+}
+
+// New initializes assets with filesystem and URL prefix.
+func New(fsys fs.FS, prefix string) *Assets {
+	return &Assets{
+		FS:     fsys,
+		Prefix: prefix,
+		hashes: map[string]fingerprint{},
+	}
+}
+
+// Default assets, built from global configuration on first use.
+var (
+	defaultAssets *Assets
+	defaultOnce   sync.Once
+)
+
+// Default returns assets, configured with global configuration.
+func Default() *Assets {
+	defaultOnce.Do(func() {
+		var fsys fs.FS = os.DirFS(ASSETS_DIR)
+		if ASSETS_EMBEDFS != nil {
+			sub, err := fs.Sub(ASSETS_EMBEDFS, ASSETS_DIR)
+			if err != nil {
+				panic(err)
+			}
+			fsys = sub
+		}
+		defaultAssets = New(fsys, ASSETS_PREFIX)
+	})
+	return defaultAssets
+}
+
+// Handler returns default assets handler.
+// Register it with the same prefix as ASSETS_PREFIX.
+//
+//	mux.Handle(assets.ASSETS_PREFIX, assets.Handler())
+func Handler() http.Handler {
+	return Default()
+}
+
+// hash returns a content hash of the file.
+// Hashes are cached until file modification time or size changes.
+func (a *Assets) hash(name string) (string, error) {
+	// Stat file
+	info, err := fs.Stat(a.FS, name)
+	if err != nil {
+		return "", err
+	}
+	// Lookup cache
+	a.mu.Lock()
+	cached, ok := a.hashes[name]
+	a.mu.Unlock()
+	if ok && cached.modified.Equal(info.ModTime()) && cached.size == info.Size() {
+		return cached.hash, nil
+	}
+	// Compute hash
+	content, err := fs.ReadFile(a.FS, name)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+	// Store
+	a.mu.Lock()
+	if a.hashes == nil {
+		a.hashes = map[string]fingerprint{}
+	}
+	a.hashes[name] = fingerprint{modified: info.ModTime(), size: info.Size(), hash: hash}
+	a.mu.Unlock()
+	return hash, nil
+}
+
+// URL returns a fingerprinted URL of the asset (f.e. /static/app.3f2a9c1d0b.css).
+// Returns not fingerprinted URL if the file can't be read.
+func (a *Assets) URL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	// Resolve hash
+	hash, err := a.hash(name)
+	if err != nil {
+		return a.Prefix + name
+	}
+	// Insert hash before extension
+	ext := path.Ext(name)
+	return a.Prefix + strings.TrimSuffix(name, ext) + "." + hash + ext
+}
+
+// ServeHTTP serves assets.
+// Fingerprinted URLs with actual hash get immutable cache headers,
+// other URLs must be revalidated.
+func (a *Assets) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	// Resolve name
+	name := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, a.Prefix), "/")
+	immutable := false
+	if _, err := fs.Stat(a.FS, name); err != nil {
+		// Try to resolve fingerprinted name
+		original, hash, ok := unfingerprint(name)
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		actual, err := a.hash(original)
+		if err != nil {
+			http.NotFound(w, r)
+			return
+		}
+		name, immutable = original, actual == hash
+	}
+	// Set cache headers
+	if immutable {
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+	} else {
+		w.Header().Set("Cache-Control", "no-cache")
+	}
+	// Serve
+	content, err := fs.ReadFile(a.FS, name)
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	info, _ := fs.Stat(a.FS, name)
+	var modified time.Time
+	if info != nil {
+		modified = info.ModTime()
+	}
+	http.ServeContent(w, r, name, modified, bytes.NewReader(content))
+}
+
+// unfingerprint extracts the original name and hash from fingerprinted name.
+func unfingerprint(name string) (string, string, bool) {
+	ext := path.Ext(name)
+	stem := strings.TrimSuffix(name, ext)
+	hashExt := path.Ext(stem)
+	hash := strings.TrimPrefix(hashExt, ".")
+	if len(hash) != hashLength {
+		return "", "", false
+	}
+	if _, err := hex.DecodeString(hash); err != nil {
+		return "", "", false
+	}
+	return strings.TrimSuffix(stem, hashExt) + ext, hash, true
+}
@@ -0,0 +1,77 @@
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func TestServeHTTP(t *testing.T) {
+	// Build assets, with a secret file outside of the assets directory
+	dir := t.TempDir()
+	os.WriteFile(filepath.Join(dir, "secret.txt"), []byte("secret"), 0o644)
+	os.MkdirAll(filepath.Join(dir, "static", "css"), 0o755)
+	os.WriteFile(filepath.Join(dir, "static", "css", "app.css"), []byte("body{}"), 0o644)
+	a := New(os.DirFS(filepath.Join(dir, "static")), "/static/")
+	url := a.URL("css/app.css")
+	tests := []struct {
+		path   string
+		status int
+		cache  string
+	}{
+		{url, http.StatusOK, "public, max-age=31536000, immutable"},
+		{"/static/css/app.css", http.StatusOK, "no-cache"},
+		{"/static/css/app.0123456789.css", http.StatusOK, "no-cache"},
+		{"/static/css/missing.0123456789.css", http.StatusNotFound, ""},
+		{"/static/css", http.StatusNotFound, ""},
+		{"/static/", http.StatusNotFound, ""},
+		{"/static/../secret.txt", http.StatusNotFound, ""},
+		{"/static/css/../../secret.txt", http.StatusNotFound, ""},
+		{"/static//secret.txt", http.StatusNotFound, ""},
+		{"/static/" + filepath.ToSlash(filepath.Join(dir, "secret.txt")), http.StatusNotFound, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			// Build request directly, so the path is not cleaned
+			r := httptest.NewRequest("GET", "/", nil)
+			r.URL.Path = tt.path
+			w := httptest.NewRecorder()
+			a.ServeHTTP(w, r)
+			if w.Code != tt.status {
+				t.Errorf("status %d, expected %d", w.Code, tt.status)
+			}
+			if strings.Contains(w.Body.String(), "secret") {
+				t.Error("file outside of the assets directory is served")
+			}
+			if tt.cache != "" && w.Header().Get("Cache-Control") != tt.cache {
+				t.Errorf("Cache-Control %q, expected %q", w.Header().Get("Cache-Control"), tt.cache)
+			}
+		})
+	}
+}
+
+func TestURL(t *testing.T) {
+	content := []byte("alert(1)")
+	sum := sha256.Sum256(content)
+	hash := hex.EncodeToString(sum[:])[:hashLength]
+	a := New(fstest.MapFS{"app.js": {Data: content}}, "/static/")
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"app.js", "/static/app." + hash + ".js"},
+		{"/app.js", "/static/app." + hash + ".js"},
+		{"missing.js", "/static/missing.js"},
+	}
+	for _, tt := range tests {
+		if url := a.URL(tt.name); url != tt.want {
+			t.Errorf("URL(%q) = %q, expected %q", tt.name, url, tt.want)
+		}
+	}
+}
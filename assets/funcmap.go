@@ -0,0 +1,14 @@
+package assets
+
+import (
+	"html/template"
+)
+
+// FuncMap holds a library predefined template functions.
+// You have to include it in your template building to use assets properly.
+var FuncMap = template.FuncMap{
+	// asset returns a fingerprinted URL of the asset, served by default assets.
+	"asset": func(name string) string {
+		return Default().URL(name)
+	},
+}
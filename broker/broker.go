@@ -0,0 +1,30 @@
+package broker
+
+// Message is a unit of data, published into a topic.
+// Payload is optional, in most cases a message is just a signal
+// that the data behind the topic was changed.
+type Message struct {
+	Topic   string
+	Payload []byte
+}
+
+// Broker defines requirements for pub/sub implementations.
+// Use Memory for a single process setup,
+// or implement this interface on top of an external broker (Redis, NATS, etc.)
+// to broadcast messages across multiple instances.
+type Broker interface {
+	// Publish message into the topic.
+	Publish(topic string, payload []byte) error
+	// Subscribe to the topic.
+	// Subscription must be closed when it's not needed anymore.
+	Subscribe(topic string) (Subscription, error)
+}
+
+// Subscription is a topic subscription, created with Broker.Subscribe.
+type Subscription interface {
+	// Messages returns a channel of published messages.
+	// Channel is closed on subscription close.
+	Messages() <-chan Message
+	// Close the subscription.
+	Close() error
+}
@@ -0,0 +1,97 @@
+package broker
+
+import (
+	"sync"
+)
+
+// Memory is an in-process broker implementation.
+// Messages are delivered only to subscribers of the same process,
+// so it fits single instance deployments and local testing.
+type Memory struct {
+	Buffer int // Subscription channel buffer size (default 16)
+
+	mu            sync.RWMutex
+	subscriptions map[string]map[*memorySubscription]struct{}
+}
+
+// NewMemory initializes a new in-process broker.
+func NewMemory() *Memory {
+	return &Memory{
+		subscriptions: map[string]map[*memorySubscription]struct{}{},
+	}
+}
+
+// Publish delivers message to all topic subscribers.
+// Delivery is non-blocking, message is dropped for subscribers
+// with a full buffer (they already have pending re-render).
+func (b *Memory) Publish(topic string, payload []byte) error {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	// Deliver to each subscriber
+	for s := range b.subscriptions[topic] {
+		select {
+		case s.messages <- Message{Topic: topic, Payload: payload}:
+		default:
+		}
+	}
+	return nil
+}
+
+// Subscribe creates a new topic subscription.
+func (b *Memory) Subscribe(topic string) (Subscription, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Resolve buffer size
+	buffer := b.Buffer
+	if buffer == 0 {
+		buffer = 16
+	}
+	// Create subscription
+	s := &memorySubscription{
+		broker:   b,
+		topic:    topic,
+		messages: make(chan Message, buffer),
+	}
+	// Register subscription
+	if b.subscriptions == nil {
+		b.subscriptions = map[string]map[*memorySubscription]struct{}{}
+	}
+	if b.subscriptions[topic] == nil {
+		b.subscriptions[topic] = map[*memorySubscription]struct{}{}
+	}
+	b.subscriptions[topic][s] = struct{}{}
+	// Return
+	return s, nil
+}
+
+// unsubscribe removes subscription from the broker.
+func (b *Memory) unsubscribe(s *memorySubscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	// Pass if already removed
+	if _, ok := b.subscriptions[s.topic][s]; !ok {
+		return
+	}
+	// Remove and close
+	delete(b.subscriptions[s.topic], s)
+	if len(b.subscriptions[s.topic]) == 0 {
+		delete(b.subscriptions, s.topic)
+	}
+	close(s.messages)
+}
+
+// memorySubscription is a Memory broker subscription.
+type memorySubscription struct {
+	broker   *Memory
+	topic    string
+	messages chan Message
+}
+
+func (s *memorySubscription) Messages() <-chan Message {
+	return s.messages
+}
+
+func (s *memorySubscription) Close() error {
+	s.broker.unsubscribe(s)
+	return nil
+}
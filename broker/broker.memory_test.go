@@ -0,0 +1,44 @@
+package broker
+
+import "testing"
+
+func TestMemory(t *testing.T) {
+	b := &Memory{Buffer: 1}
+	// Subscribe
+	a, _ := b.Subscribe("a")
+	a2, _ := b.Subscribe("a")
+	other, _ := b.Subscribe("b")
+	// Publish, dropping messages for full buffers
+	b.Publish("a", []byte("first"))
+	b.Publish("a", []byte("second"))
+	for _, s := range []Subscription{a, a2} {
+		if m := <-s.Messages(); m.Topic != "a" || string(m.Payload) != "first" {
+			t.Errorf("got %q message %q, want first", m.Topic, m.Payload)
+		}
+		select {
+		case m := <-s.Messages():
+			t.Errorf("message %q is not dropped for a full buffer", m.Payload)
+		default:
+		}
+	}
+	select {
+	case m := <-other.Messages():
+		t.Errorf("message %q is delivered to another topic", m.Payload)
+	default:
+	}
+	// Close, closing the channel and removing empty topics
+	a.Close()
+	a.Close()
+	if _, ok := <-a.Messages(); ok {
+		t.Error("closed subscription channel is not closed")
+	}
+	a2.Close()
+	other.Close()
+	if len(b.subscriptions) != 0 {
+		t.Errorf("subscriptions are not removed: %v", b.subscriptions)
+	}
+	// Publish without subscribers
+	if err := b.Publish("a", nil); err != nil {
+		t.Error(err)
+	}
+}
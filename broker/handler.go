@@ -0,0 +1,102 @@
+package broker
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// Global handler configuration defaults.
+var (
+	HANDLER_HEARTBEAT = 30 * time.Second // Keep-alive comment interval
+)
+
+// MessageKey is a context store key of the message,
+// that triggered component re-render.
+const MessageKey = "broker.message"
+
+// Handler builds a http.HandlerFunc that streams component re-renders
+// with Server-Sent Events on each message, published into the request topic.
+// Topic is resolved from the request with provided function (f.e. "order:" + r.URL.Query().Get("id")).
+// Each event is named after the component, so it can be consumed
+// with htmx sse extension (sse-swap="ComponentName").
+func Handler(b Broker, topic func(r *http.Request) string, c component.Component) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		// Ensure streaming is supported
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			panic("response writer does not support flushing")
+		}
+		// Subscribe to the topic
+		subscription, err := b.Subscribe(topic(r))
+		if err != nil {
+			panic(err)
+		}
+		defer subscription.Close()
+		// Write event stream headers
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+		w.WriteHeader(http.StatusOK)
+		flusher.Flush()
+		// Stream until client disconnects
+		heartbeat := time.NewTicker(HANDLER_HEARTBEAT)
+		defer heartbeat.Stop()
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case <-heartbeat.C:
+				fmt.Fprint(w, ": heartbeat\n\n")
+				flusher.Flush()
+			case message, ok := <-subscription.Messages():
+				// Subscription was closed by broker
+				if !ok {
+					return
+				}
+				// Render and send an event
+				name, html := render(w, r, c, message)
+				fmt.Fprintf(w, "event: %s\n", name)
+				for _, line := range strings.Split(html, "\n") {
+					fmt.Fprintf(w, "data: %s\n", line)
+				}
+				fmt.Fprint(w, "\n")
+				flusher.Flush()
+			}
+		}
+	}
+}
+
+// render executes component in the scope of the message
+// and returns resulting component name and markup.
+func render(w http.ResponseWriter, r *http.Request, c component.Component, message Message) (string, string) {
+	// Create context and provide message
+	ctx := component.NewContext(w, r)
+	ctx.Set(MessageKey, message)
+	// Build state
+	state := c(ctx)
+	// Inject component name, unless it's already set
+	if state.GetName() == "" {
+		state.SetName(c.GetName())
+	}
+	// Bind state to the context and initialize
+	component.Bind(ctx, state)
+	component.Init(ctx, state)
+	// Ensure state implements render
+	renderer, ok := state.(rendering.Renderer)
+	if !ok {
+		panic("The component does not implement rendering")
+	}
+	// Render
+	var out strings.Builder
+	component.BeforeRender(ctx, state)
+	if err := renderer.Render(state, &out); err != nil {
+		panic(err)
+	}
+	component.AfterRender(ctx, state)
+	return state.GetName(), out.String()
+}
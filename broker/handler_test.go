@@ -0,0 +1,124 @@
+package broker
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+type testState struct {
+	component.Disposable
+	rendering.Func
+}
+
+// testStatus renders the payload of the message, that triggered re-render.
+func testStatus(ctx *component.Context) component.State {
+	message := ctx.Get(MessageKey).(Message)
+	state := &testState{}
+	state.Writer = func(w io.Writer) error {
+		_, err := io.WriteString(w, string(message.Payload))
+		return err
+	}
+	return state
+}
+
+// connect starts a handler server and opens an event stream.
+func connect(t *testing.T, b *Memory) (*bufio.Reader, context.CancelFunc) {
+	t.Helper()
+	server := httptest.NewServer(Handler(b, func(r *http.Request) string {
+		return "order:" + r.URL.Query().Get("id")
+	}, testStatus))
+	t.Cleanup(server.Close)
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	r, _ := http.NewRequestWithContext(ctx, "GET", server.URL+"/?id=1", nil)
+	// Headers are flushed right away, before any message
+	resp, err := http.DefaultClient.Do(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	if ct := resp.Header.Get("Content-Type"); ct != "text/event-stream" {
+		t.Errorf("content type is %q", ct)
+	}
+	return bufio.NewReader(resp.Body), cancel
+}
+
+// event reads a single event from the stream.
+func event(t *testing.T, r *bufio.Reader) string {
+	t.Helper()
+	var lines []string
+	for {
+		line, err := r.ReadString('\n')
+		if err != nil {
+			t.Fatal(err)
+		}
+		if line == "\n" {
+			return strings.Join(lines, "")
+		}
+		lines = append(lines, line)
+	}
+}
+
+// subscribers returns a number of subscribers of the topic.
+func subscribers(b *Memory, topic string) int {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.subscriptions[topic])
+}
+
+func TestHandler(t *testing.T) {
+	b := NewMemory()
+	stream, cancel := connect(t, b)
+	if n := subscribers(b, "order:1"); n != 1 {
+		t.Fatalf("got %d subscribers, want 1", n)
+	}
+	// Publish renders and flushes an event
+	b.Publish("order:2", []byte("other"))
+	b.Publish("order:1", []byte("shipped\nsoon"))
+	if e, want := event(t, stream), "event: testStatus\ndata: shipped\ndata: soon\n"; e != want {
+		t.Errorf("got event %q, want %q", e, want)
+	}
+	// Client disconnect closes the subscription
+	cancel()
+	deadline := time.Now().Add(time.Second)
+	for subscribers(b, "order:1") != 0 {
+		if time.Now().After(deadline) {
+			t.Fatal("subscription is not closed on client disconnect")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestHandlerHeartbeat(t *testing.T) {
+	defer func(heartbeat time.Duration) { HANDLER_HEARTBEAT = heartbeat }(HANDLER_HEARTBEAT)
+	HANDLER_HEARTBEAT = 10 * time.Millisecond
+	stream, _ := connect(t, NewMemory())
+	if e := event(t, stream); e != ": heartbeat\n" {
+		t.Errorf("got %q, want heartbeat comment", e)
+	}
+}
+
+func TestHandlerClosed(t *testing.T) {
+	b := NewMemory()
+	stream, _ := connect(t, b)
+	// Subscription, closed by broker, ends the stream
+	b.mu.RLock()
+	var subscription *memorySubscription
+	for s := range b.subscriptions["order:1"] {
+		subscription = s
+	}
+	b.mu.RUnlock()
+	subscription.Close()
+	if _, err := stream.ReadString('\n'); err != io.EOF {
+		t.Errorf("stream is not ended (%v)", err)
+	}
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// Store defines requirements for cache backends.
+// Use Memory for a single process setup,
+// or implement this interface on top of an external storage (Redis, Memcached, etc.).
+type Store interface {
+	// Get returns a cached value by key.
+	Get(key string) ([]byte, bool)
+	// Set stores a value by key with TTL (zero means no expiration) and tags.
+	Set(key string, value []byte, ttl time.Duration, tags []string)
+	// Invalidate removes all values, marked with any of provided tags.
+	Invalidate(tags ...string)
+}
+
+// Policy defines caching behavior for a component.
+//
+// Default key is the request URI only, so it's the same for every user.
+// Requests with credentials (Cookie or Authorization header) might render per-user content,
+// so they are not cached unless Key is set explicitly.
+// Explicit Key is responsible to include user identity, if content depends on it.
+type Policy struct {
+	Name string                                // Unique cache name (registered name or function path by default)
+	Key  func(ctx *component.Context) string   // User defined key (request URI by default, required for requests with credentials)
+	TTL  time.Duration                         // Entry TTL (no expiration by default)
+	Tags func(ctx *component.Context) []string // Invalidation tags (no tags by default)
+}
+
+// cacheable checks if the request within the context might be cached.
+// Requests with credentials are cached only with explicit key.
+func (p Policy) cacheable(ctx *component.Context) bool {
+	if p.Key != nil {
+		return true
+	}
+	return ctx.Request.Header.Get("Cookie") == "" && ctx.Request.Header.Get("Authorization") == ""
+}
+
+// key builds a cache key of the component within the context.
+func (p Policy) key(prefix string, ctx *component.Context, c component.Component) string {
+	key := ctx.Request.URL.RequestURI()
+	if p.Key != nil {
+		key = p.Key(ctx)
+	}
+	return prefix + ":" + p.name(c) + ":" + key
+}
+
+// name resolves a unique cache name of the component.
+// Wrapped components (f.e. component.Guard) and closures
+// can't be distinguished by function, so they require explicit name.
+func (p Policy) name(c component.Component) string {
+	// Explicit name
+	if p.Name != "" {
+		return p.Name
+	}
+	// Registered name
+	if name, ok := component.NameOf(c); ok {
+		return name
+	}
+	// Function path, if it's not a closure or a method value
+	path := runtime.FuncForPC(reflect.ValueOf(c).Pointer()).Name()
+	tokens := strings.Split(path, ".")
+	if !strings.HasPrefix(tokens[len(tokens)-1], "func") && !strings.HasSuffix(path, "-fm") {
+		return path
+	}
+	panic(fmt.Sprintf("cache requires Policy.Name for anonymous component %s", path))
+}
+
+// tags resolves invalidation tags within the context.
+func (p Policy) tags(ctx *component.Context) []string {
+	if p.Tags == nil {
+		return nil
+	}
+	return p.Tags(ctx)
+}
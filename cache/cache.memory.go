@@ -0,0 +1,96 @@
+package cache
+
+import (
+	"sync"
+	"time"
+)
+
+// Memory is an in-process cache store.
+// Expired entries are removed lazily, on access.
+type Memory struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	tags    map[string]map[string]struct{}
+}
+
+// memoryEntry is a Memory store entry.
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+	tags    []string
+}
+
+// NewMemory initializes a new in-process cache store.
+func NewMemory() *Memory {
+	return &Memory{
+		entries: map[string]memoryEntry{},
+		tags:    map[string]map[string]struct{}{},
+	}
+}
+
+func (s *Memory) Get(key string) ([]byte, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Lookup
+	entry, ok := s.entries[key]
+	if !ok {
+		return nil, false
+	}
+	// Remove if expired
+	if !entry.expires.IsZero() && time.Now().After(entry.expires) {
+		s.remove(key)
+		return nil, false
+	}
+	return entry.value, true
+}
+
+func (s *Memory) Set(key string, value []byte, ttl time.Duration, tags []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Initialize, if needed
+	if s.entries == nil {
+		s.entries = map[string]memoryEntry{}
+		s.tags = map[string]map[string]struct{}{}
+	}
+	// Remove previous entry
+	s.remove(key)
+	// Store entry
+	entry := memoryEntry{value: value, tags: tags}
+	if ttl > 0 {
+		entry.expires = time.Now().Add(ttl)
+	}
+	s.entries[key] = entry
+	// Index tags
+	for _, tag := range tags {
+		if s.tags[tag] == nil {
+			s.tags[tag] = map[string]struct{}{}
+		}
+		s.tags[tag][key] = struct{}{}
+	}
+}
+
+func (s *Memory) Invalidate(tags ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, tag := range tags {
+		for key := range s.tags[tag] {
+			s.remove(key)
+		}
+	}
+}
+
+// remove deletes an entry with its tags index.
+// Must be called under lock.
+func (s *Memory) remove(key string) {
+	entry, ok := s.entries[key]
+	if !ok {
+		return
+	}
+	delete(s.entries, key)
+	for _, tag := range entry.tags {
+		delete(s.tags[tag], key)
+		if len(s.tags[tag]) == 0 {
+			delete(s.tags, tag)
+		}
+	}
+}
@@ -0,0 +1,202 @@
+package cache
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+type testState struct {
+	component.Disposable
+	rendering.Func
+}
+
+// text builds a state, rendering provided text.
+func text(s string) *testState {
+	state := &testState{}
+	state.Writer = func(w io.Writer) error {
+		_, err := io.WriteString(w, s)
+		return err
+	}
+	return state
+}
+
+// counted builds a page component, counting its executions.
+func counted(calls *atomic.Int32) component.Component {
+	return func(ctx *component.Context) component.State {
+		calls.Add(1)
+		return text(ctx.Request.Header.Get("Accept"))
+	}
+}
+
+var (
+	testFragment = component.Register("cache.Fragment", func(ctx *component.Context) component.State { return text("A") })
+	testOther    = component.Register("cache.Other", func(ctx *component.Context) component.State { return text("B") })
+)
+
+func testNamed(ctx *component.Context) component.State {
+	return text("C")
+}
+
+// get performs a GET request to the handler.
+func get(h http.HandlerFunc, header http.Header) *httptest.ResponseRecorder {
+	r := httptest.NewRequest("GET", "/", nil)
+	for k, v := range header {
+		r.Header[k] = v
+	}
+	w := httptest.NewRecorder()
+	h(w, r)
+	return w
+}
+
+func TestMiddlewareSetCookie(t *testing.T) {
+	store := NewMemory()
+	policy := Policy{Name: "cookie"}
+	user := ""
+	page := func(ctx *component.Context) component.State {
+		http.SetCookie(ctx.ResponseWriter, &http.Cookie{Name: "session", Value: user})
+		return text("page")
+	}
+	h := rendering.Handler(page, Middleware(store, page, policy))
+	// Alice
+	user = "alice"
+	get(h, nil)
+	// Bob must not get Alice's cookie
+	user = "bob"
+	w := get(h, nil)
+	if cookie := w.Header().Get("Set-Cookie"); !strings.Contains(cookie, "bob") {
+		t.Errorf("got cookie %q, want bob's session", cookie)
+	}
+}
+
+func TestMiddlewareVary(t *testing.T) {
+	store := NewMemory()
+	calls := atomic.Int32{}
+	page := counted(&calls)
+	h := rendering.Handler(page, Middleware(store, page, Policy{Name: "vary"}))
+	tests := []struct {
+		accept string
+		calls  int32
+	}{
+		{"text/html", 1},
+		{"application/json", 2},
+		{"text/html", 2},
+		{"application/json", 2},
+	}
+	for i, tt := range tests {
+		w := get(h, http.Header{"Accept": {tt.accept}})
+		if body := w.Body.String(); body != tt.accept {
+			t.Errorf("request %d: got %q, want %q", i, body, tt.accept)
+		}
+		if n := calls.Load(); n != tt.calls {
+			t.Errorf("request %d: component executed %d times, want %d", i, n, tt.calls)
+		}
+	}
+}
+
+func TestMiddlewareCredentials(t *testing.T) {
+	tests := []struct {
+		name   string
+		header http.Header
+		key    func(ctx *component.Context) string
+		calls  int32
+	}{
+		{"anonymous", http.Header{"Accept": {"text/html"}}, nil, 1},
+		{"cookie", http.Header{"Accept": {"text/html"}, "Cookie": {"session=alice"}}, nil, 3},
+		{"authorization", http.Header{"Accept": {"text/html"}, "Authorization": {"Bearer alice"}}, nil, 3},
+		{"explicit key", http.Header{"Accept": {"text/html"}, "Cookie": {"session=alice"}}, func(ctx *component.Context) string { return "alice" }, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemory()
+			calls := atomic.Int32{}
+			page := counted(&calls)
+			h := rendering.Handler(page, Middleware(store, page, Policy{Name: "credentials", Key: tt.key}))
+			for i := 0; i < 3; i++ {
+				get(h, tt.header)
+			}
+			if n := calls.Load(); n != tt.calls {
+				t.Errorf("component executed %d times, want %d", n, tt.calls)
+			}
+		})
+	}
+}
+
+func TestUseCredentials(t *testing.T) {
+	store := NewMemory()
+	calls := atomic.Int32{}
+	fragment := counted(&calls)
+	page := func(ctx *component.Context) component.State {
+		f := Use(ctx, store, fragment, Policy{Name: "credentials"})
+		state := &testState{}
+		state.Writer = func(w io.Writer) error {
+			state := f()
+			return state.(rendering.Renderer).Render(state, w)
+		}
+		return state
+	}
+	for i := 0; i < 2; i++ {
+		get(rendering.Handler(page), http.Header{"Accept": {"text/html"}, "Cookie": {"session=alice"}})
+	}
+	if n := calls.Load(); n != 2 {
+		t.Errorf("component executed %d times, want 2", n)
+	}
+}
+
+func TestUseNames(t *testing.T) {
+	store := NewMemory()
+	page := func(ctx *component.Context) component.State {
+		a := Use(ctx, store, testFragment, Policy{})
+		b := Use(ctx, store, testOther, Policy{})
+		state := &testState{}
+		state.Writer = func(w io.Writer) error {
+			for _, f := range []component.Future{a, b} {
+				state := f()
+				if err := state.(rendering.Renderer).Render(state, w); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+		return state
+	}
+	for i := 0; i < 2; i++ {
+		if body := get(rendering.Handler(page), nil).Body.String(); body != "AB" {
+			t.Errorf("request %d: got %q, want AB", i, body)
+		}
+	}
+}
+
+func TestPolicyName(t *testing.T) {
+	tests := []struct {
+		name      string
+		policy    Policy
+		component component.Component
+		want      string
+	}{
+		{"explicit", Policy{Name: "grid"}, testFragment, "grid"},
+		{"registered", Policy{}, testFragment, "cache.Fragment"},
+		{"function", Policy{}, testNamed, "go.kyoto.codes/v3/cache.testNamed"},
+		{"closure", Policy{}, component.Guard(testFragment), ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			defer func() {
+				if r := recover(); r != nil && tt.want != "" {
+					t.Errorf("unexpected panic: %v", r)
+				} else if r == nil && tt.want == "" {
+					t.Error("anonymous component doesn't panic")
+				}
+			}()
+			if name := tt.policy.name(tt.component); name != tt.want {
+				t.Errorf("got %q, want %q", name, tt.want)
+			}
+		})
+	}
+}
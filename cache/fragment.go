@@ -0,0 +1,84 @@
+package cache
+
+import (
+	"bytes"
+	"io"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// Fragment is a cached component state.
+// On cache hit it holds rendered markup only,
+// on cache miss it renders the actual component and stores the output.
+type Fragment struct {
+	component.Disposable
+
+	key    string
+	html   []byte
+	future component.Future
+	store  Store
+	policy Policy
+}
+
+// Use allows you to use your components with cached rendering output.
+// On cache hit, component is not executed at all.
+// Requests with credentials (Cookie or Authorization header) are cached only with explicit Policy.Key,
+// otherwise component is used as is.
+// Render the future with `render` function.
+func Use(ctx *component.Context, store Store, c component.Component, policy Policy) component.Future {
+	// Use component as is, if not cacheable
+	if !policy.cacheable(ctx) {
+		return component.Use(ctx, c)
+	}
+	// Build fragment
+	fragment := &Fragment{
+		key:    policy.key("fragment", ctx, c),
+		store:  store,
+		policy: policy,
+	}
+	fragment.SetName(c.GetName())
+	// Resolve from cache or execute component
+	if html, ok := store.Get(fragment.key); ok {
+		fragment.html = html
+	} else {
+		fragment.future = component.Use(ctx, c)
+	}
+	// Bind fragment
+	component.Bind(ctx, fragment)
+	// Return getter
+	return func() component.State {
+		return fragment
+	}
+}
+
+func (f *Fragment) RenderSkip() bool {
+	return false
+}
+
+func (f *Fragment) Render(_ component.State, w io.Writer) error {
+	// Write cached markup
+	if f.future == nil {
+		_, err := w.Write(f.html)
+		return err
+	}
+	// Await actual state
+	state := f.future()
+	renderer, ok := state.(rendering.Renderer)
+	if !ok {
+		panic("state does not implement render")
+	}
+	// Render actual state
+	ctx := component.ContextOf(state)
+	out := &bytes.Buffer{}
+	component.BeforeRender(ctx, state)
+	if err := renderer.Render(state, out); err != nil {
+		return err
+	}
+	component.AfterRender(ctx, state)
+	// Store output
+	f.store.Set(f.key, out.Bytes(), f.policy.TTL, f.policy.tags(f.GetContext()))
+	// Write
+	_, err := out.WriteTo(w)
+	return err
+}
@@ -0,0 +1,144 @@
+package cache
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+	"go.kyoto.codes/zen/v3/errorsx"
+)
+
+// page is a cached handler response.
+type page struct {
+	Status int
+	Header http.Header
+	Body   []byte
+}
+
+// PAGE_VARY holds request headers, which always vary cached responses.
+// Content negotiation (Accept) and htmx partial rendering (HX-Request)
+// serve different content on the same URI.
+// Headers from response Vary header are respected too.
+var PAGE_VARY = []string{"Accept", "HX-Request"}
+
+// Middleware builds a rendering handler middleware,
+// which caches whole responses of provided component.
+// Only successful GET requests are cached.
+// Requests with credentials (Cookie or Authorization header) are cached only with explicit Policy.Key.
+// Responses with per-user data (Set-Cookie header, private or no-store Cache-Control)
+// are not cached.
+//
+//	mux.HandleFunc("/", rendering.Handler(Page, cache.Middleware(store, Page, cache.Policy{TTL: time.Minute})))
+func Middleware(store Store, c component.Component, policy Policy) rendering.Middleware {
+	return func(ctx *component.Context, next func()) {
+		// Pass non-cacheable requests
+		if ctx.Request.Method != http.MethodGet || !policy.cacheable(ctx) {
+			next()
+			return
+		}
+		// Resolve variant key, with headers from the stored Vary list
+		base := policy.key("page", ctx, c)
+		vary := varied(http.Header{})
+		if value, ok := store.Get(base + ":vary"); ok {
+			json.Unmarshal(value, &vary)
+		}
+		key := variant(base, ctx.Request, vary)
+		// Serve from cache, if possible
+		if value, ok := store.Get(key); ok {
+			cached := page{}
+			if err := json.Unmarshal(value, &cached); err == nil {
+				for k, v := range cached.Header {
+					ctx.ResponseWriter.Header()[k] = v
+				}
+				ctx.ResponseWriter.WriteHeader(cached.Status)
+				ctx.ResponseWriter.Write(cached.Body)
+				return
+			}
+		}
+		// Record response
+		rec := &recorder{ResponseWriter: ctx.ResponseWriter, status: http.StatusOK}
+		ctx.ResponseWriter = rec
+		next()
+		ctx.ResponseWriter = rec.ResponseWriter
+		// Store successful, non-empty, shared response
+		if rec.status == http.StatusOK && rec.body.Len() > 0 && shared(rec.Header()) {
+			// Store Vary list
+			vary = varied(rec.Header())
+			store.Set(base+":vary", errorsx.Must(json.Marshal(vary)), policy.TTL, policy.tags(ctx))
+			// Store response
+			value, err := json.Marshal(page{
+				Status: rec.status,
+				Header: rec.Header().Clone(),
+				Body:   rec.body.Bytes(),
+			})
+			if err != nil {
+				panic(err)
+			}
+			store.Set(variant(base, ctx.Request, vary), value, policy.TTL, policy.tags(ctx))
+		}
+	}
+}
+
+// shared checks if the response doesn't contain per-user data.
+func shared(header http.Header) bool {
+	if len(header.Values("Set-Cookie")) > 0 {
+		return false
+	}
+	if strings.Contains(strings.Join(header.Values("Vary"), ","), "*") {
+		return false
+	}
+	cc := strings.ToLower(strings.Join(header.Values("Cache-Control"), ","))
+	return !strings.Contains(cc, "private") && !strings.Contains(cc, "no-store")
+}
+
+// varied returns a list of headers, varying the response
+// (PAGE_VARY and response Vary header).
+func varied(header http.Header) []string {
+	names, seen := []string{}, map[string]bool{}
+	for _, value := range append(append([]string{}, PAGE_VARY...), header.Values("Vary")...) {
+		for _, name := range strings.Split(value, ",") {
+			name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+			if name != "" && !seen[name] {
+				names, seen[name] = append(names, name), true
+			}
+		}
+	}
+	return names
+}
+
+// variant builds a response variant key from the request headers.
+func variant(base string, r *http.Request, vary []string) string {
+	key := strings.Builder{}
+	key.WriteString(base)
+	for _, name := range vary {
+		key.WriteString("|" + name + "=" + strings.Join(r.Header.Values(name), ","))
+	}
+	return key.String()
+}
+
+// recorder is a response writer,
+// which records status and body while writing.
+type recorder struct {
+	http.ResponseWriter
+	status int
+	body   bytes.Buffer
+}
+
+func (r *recorder) WriteHeader(status int) {
+	r.status = status
+	r.ResponseWriter.WriteHeader(status)
+}
+
+func (r *recorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
+
+func (r *recorder) Flush() {
+	if f, ok := r.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
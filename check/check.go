@@ -0,0 +1,175 @@
+package check
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"text/template/parse"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// builtins is a list of text/template predefined functions.
+var builtins = []string{
+	"and", "call", "html", "index", "slice", "js", "len", "not", "or",
+	"print", "printf", "println", "urlquery", "eq", "ge", "gt", "le", "lt", "ne",
+}
+
+// Issue is a template issue, found by the checker.
+type Issue struct {
+	Template string // Template (define) name
+	Location string // Location in the source (file:line:col)
+	Message  string // Issue description
+}
+
+func (i Issue) String() string {
+	return fmt.Sprintf("%s: %s: %s", i.Location, i.Template, i.Message)
+}
+
+// Checker statically checks templates against component state types.
+// It reports references to nonexistent fields and methods,
+// calls to unknown functions and undefined templates.
+type Checker struct {
+	Funcs map[string]any          // Known template functions (rendering.FuncMapAll by default)
+	Types map[string]reflect.Type // State types by template (define) name (registered state types by default)
+
+	trees   map[string]*parse.Tree
+	files   map[*parse.Tree]string
+	visited map[string]bool
+	issues  []Issue
+}
+
+// State maps the template (define) name to the component state type.
+// Use component registered names, as they are used as template names.
+func (c *Checker) State(name string, state component.State) *Checker {
+	if c.Types == nil {
+		c.Types = map[string]reflect.Type{}
+	}
+	c.Types[name] = reflect.TypeOf(state)
+	return c
+}
+
+// ParseFS parses templates, matching the glob patterns, from the filesystem.
+func (c *Checker) ParseFS(fsys fs.FS, patterns ...string) error {
+	for _, pattern := range patterns {
+		// Resolve files
+		files, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return err
+		}
+		// Parse each file
+		for _, file := range files {
+			text, err := fs.ReadFile(fsys, file)
+			if err != nil {
+				return err
+			}
+			if err := c.Parse(file, string(text)); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// ParseGlob parses templates, matching the glob pattern, from the disk.
+func (c *Checker) ParseGlob(pattern string) error {
+	files, err := filepath.Glob(pattern)
+	if err != nil {
+		return err
+	}
+	for _, file := range files {
+		text, err := os.ReadFile(file)
+		if err != nil {
+			return err
+		}
+		if err := c.Parse(file, string(text)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Parse parses a template source.
+// Unknown functions are not failing the parsing, they are reported by Check.
+func (c *Checker) Parse(file, text string) error {
+	// Initialize
+	if c.trees == nil {
+		c.trees = map[string]*parse.Tree{}
+		c.files = map[*parse.Tree]string{}
+	}
+	// Parse into a tree set
+	trees := map[string]*parse.Tree{}
+	tree := parse.New(file)
+	tree.Mode = parse.SkipFuncCheck
+	if _, err := tree.Parse(text, "", "", trees); err != nil {
+		return err
+	}
+	// Store
+	for name, t := range trees {
+		c.trees[name] = t
+		c.files[t] = file
+	}
+	return nil
+}
+
+// Check checks parsed templates and returns found issues, in templates order.
+func (c *Checker) Check() []Issue {
+	// Defaults
+	if c.Funcs == nil {
+		c.Funcs = rendering.FuncMapAll
+	}
+	c.issues = nil
+	c.visited = map[string]bool{}
+	// Check each template in stable order
+	names := make([]string, 0, len(c.trees))
+	for name := range c.trees {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		c.template(name, c.stateType(name))
+	}
+	return c.issues
+}
+
+// stateType resolves a state type of the template,
+// explicitly provided or registered alongside the component name.
+func (c *Checker) stateType(name string) reflect.Type {
+	if t, ok := c.Types[name]; ok {
+		return t
+	}
+	t, _ := component.StateType(name)
+	return t
+}
+
+// template checks a template with provided dot type (nil means unknown).
+func (c *Checker) template(name string, dot reflect.Type) {
+	// Check each template/type combination once
+	key := fmt.Sprintf("%s:%v", name, dot)
+	if c.visited[key] {
+		return
+	}
+	c.visited[key] = true
+	// Skip empty trees (file roots without content)
+	tree := c.trees[name]
+	if tree == nil || tree.Root == nil {
+		return
+	}
+	// Walk
+	s := &scope{checker: c, tree: tree, name: name, vars: map[string]reflect.Type{"$": dot}}
+	s.list(tree.Root, dot)
+}
+
+// report registers an issue.
+func (c *Checker) report(tree *parse.Tree, name string, node parse.Node, format string, args ...any) {
+	location, _ := tree.ErrorContext(node)
+	c.issues = append(c.issues, Issue{
+		Template: name,
+		Location: location,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
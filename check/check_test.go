@@ -0,0 +1,59 @@
+package check
+
+import (
+	"strings"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type checkState struct {
+	component.Disposable
+
+	Count int
+	Items []string
+}
+
+func (*checkState) Total() int {
+	return 0
+}
+
+var _ = component.Register("check.Registered", func(ctx *component.Context) component.State {
+	return &checkState{}
+}, &checkState{})
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name   string
+		source string
+		issues []string
+	}{
+		{"registered fields", `{{ define "check.Registered" }}{{ .Count }}{{ .Total }}{{ end }}`, nil},
+		{"registered typo", `{{ define "check.Registered" }}{{ .Fooo }}{{ end }}`, []string{"Fooo"}},
+		{"explicit typo", `{{ define "Explicit" }}{{ .Cuont }}{{ end }}`, []string{"Cuont"}},
+		{"unknown type", `{{ define "Unknown" }}{{ .Anything }}{{ end }}`, nil},
+		{"unknown function", `{{ define "Unknown" }}{{ nofunc 1 }}{{ end }}`, []string{"nofunc"}},
+		{"range variables scope", `{{ define "check.Registered" }}{{ $x := . }}{{ range $x := .Items }}{{ end }}{{ $x.Count }}{{ end }}`, nil},
+		{"with variables scope", `{{ define "check.Registered" }}{{ $x := . }}{{ with $x := .Items }}{{ end }}{{ $x.Count }}{{ end }}`, nil},
+		{"if variables scope", `{{ define "check.Registered" }}{{ $x := . }}{{ if $x := .Items }}{{ else }}{{ $x.Count }}{{ end }}{{ $x.Count }}{{ end }}`, []string{"Count"}},
+		{"undefined template", `{{ define "Unknown" }}{{ template "missing" . }}{{ end }}`, []string{"missing"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := &Checker{}
+			checker.State("Explicit", &checkState{})
+			if err := checker.Parse("test.html", tt.source); err != nil {
+				t.Fatal(err)
+			}
+			issues := checker.Check()
+			if len(issues) != len(tt.issues) {
+				t.Fatalf("got issues %v, want %d", issues, len(tt.issues))
+			}
+			for i, issue := range issues {
+				if !strings.Contains(issue.Message, tt.issues[i]) {
+					t.Errorf("issue %q doesn't mention %q", issue, tt.issues[i])
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,62 @@
+package check
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"go.kyoto.codes/v3/rendering"
+)
+
+// Main runs templates checking as a command with provided arguments
+// and returns an exit code. Flags:
+//
+//	-glob  Comma-separated templates glob patterns (rendering.TEMPLATE_GLOB by default)
+//	-funcs Comma-separated project-specific template function names
+//
+// State types are resolved from the components registry,
+// so components have to be registered with state prototypes
+// in the packages, linked into the program.
+func Main(args []string) int {
+	return run(args, os.Stdout, os.Stderr)
+}
+
+// run runs templates checking with provided arguments and outputs.
+func run(args []string, stdout, stderr io.Writer) int {
+	// Parse flags
+	flags := flag.NewFlagSet("kyoto-check", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	glob := flags.String("glob", rendering.TEMPLATE_GLOB, "Comma-separated templates glob patterns")
+	funcs := flags.String("funcs", "", "Comma-separated project-specific template function names")
+	if err := flags.Parse(args); err != nil {
+		return 2
+	}
+	// Build checker
+	checker := &Checker{Funcs: map[string]any{}}
+	for name, fn := range rendering.FuncMapAll {
+		checker.Funcs[name] = fn
+	}
+	for _, name := range strings.Split(*funcs, ",") {
+		if name = strings.TrimSpace(name); name != "" {
+			checker.Funcs[name] = nil
+		}
+	}
+	// Parse templates
+	for _, pattern := range strings.Split(*glob, ",") {
+		if err := checker.ParseGlob(strings.TrimSpace(pattern)); err != nil {
+			fmt.Fprintln(stderr, err)
+			return 1
+		}
+	}
+	// Check and report
+	issues := checker.Check()
+	for _, issue := range issues {
+		fmt.Fprintln(stdout, issue)
+	}
+	if len(issues) > 0 {
+		return 1
+	}
+	return 0
+}
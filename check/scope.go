@@ -0,0 +1,226 @@
+package check
+
+import (
+	"reflect"
+	"text/template/parse"
+)
+
+// scope is a template walking scope.
+type scope struct {
+	checker *Checker
+	tree    *parse.Tree
+	name    string
+	vars    map[string]reflect.Type
+}
+
+// child creates a nested scope, inheriting variables.
+func (s *scope) child() *scope {
+	vars := make(map[string]reflect.Type, len(s.vars))
+	for k, v := range s.vars {
+		vars[k] = v
+	}
+	return &scope{checker: s.checker, tree: s.tree, name: s.name, vars: vars}
+}
+
+// list checks a list of nodes.
+func (s *scope) list(list *parse.ListNode, dot reflect.Type) {
+	if list == nil {
+		return
+	}
+	for _, node := range list.Nodes {
+		s.node(node, dot)
+	}
+}
+
+// node checks a single node.
+func (s *scope) node(node parse.Node, dot reflect.Type) {
+	switch node := node.(type) {
+	case *parse.ActionNode:
+		s.pipe(node.Pipe, dot)
+	case *parse.IfNode:
+		// Pipeline variables are visible within the control structure only
+		inner := s.child()
+		inner.pipe(node.Pipe, dot)
+		inner.child().list(node.List, dot)
+		inner.child().list(node.ElseList, dot)
+	case *parse.RangeNode:
+		// Resolve element types
+		inner := s.child()
+		typ := inner.pipe(node.Pipe, dot)
+		key, elem := iteration(typ)
+		// Declare variables
+		switch len(node.Pipe.Decl) {
+		case 1:
+			inner.vars[node.Pipe.Decl[0].Ident[0]] = elem
+		case 2:
+			inner.vars[node.Pipe.Decl[0].Ident[0]] = key
+			inner.vars[node.Pipe.Decl[1].Ident[0]] = elem
+		}
+		inner.child().list(node.List, elem)
+		inner.child().list(node.ElseList, dot)
+	case *parse.WithNode:
+		inner := s.child()
+		typ := inner.pipe(node.Pipe, dot)
+		inner.child().list(node.List, typ)
+		inner.child().list(node.ElseList, dot)
+	case *parse.TemplateNode:
+		// Ensure template is defined
+		if _, ok := s.checker.trees[node.Name]; !ok {
+			s.checker.report(s.tree, s.name, node, "template %q is not defined", node.Name)
+			return
+		}
+		// Check called template with argument type,
+		// unless it has own registered type
+		var typ reflect.Type
+		if node.Pipe != nil {
+			typ = s.pipe(node.Pipe, dot)
+		}
+		if _, ok := s.checker.Types[node.Name]; !ok {
+			s.checker.template(node.Name, typ)
+		}
+	case *parse.ListNode:
+		s.list(node, dot)
+	}
+}
+
+// pipe checks a pipeline and returns its resulting type (nil if unknown).
+func (s *scope) pipe(pipe *parse.PipeNode, dot reflect.Type) reflect.Type {
+	if pipe == nil {
+		return nil
+	}
+	var typ reflect.Type
+	for _, cmd := range pipe.Cmds {
+		typ = s.command(cmd, dot)
+	}
+	// Declare variables
+	for _, v := range pipe.Decl {
+		s.vars[v.Ident[0]] = typ
+	}
+	return typ
+}
+
+// command checks a pipeline command and returns its resulting type.
+func (s *scope) command(cmd *parse.CommandNode, dot reflect.Type) reflect.Type {
+	var typ reflect.Type
+	for i, arg := range cmd.Args {
+		t := s.argument(arg, dot)
+		if i == 0 {
+			typ = t
+		}
+	}
+	return typ
+}
+
+// argument checks a command argument and returns its type.
+func (s *scope) argument(arg parse.Node, dot reflect.Type) reflect.Type {
+	switch arg := arg.(type) {
+	case *parse.DotNode:
+		return dot
+	case *parse.FieldNode:
+		return s.fields(arg, dot, arg.Ident)
+	case *parse.VariableNode:
+		typ, ok := s.vars[arg.Ident[0]]
+		if !ok {
+			return nil
+		}
+		return s.fields(arg, typ, arg.Ident[1:])
+	case *parse.ChainNode:
+		return s.fields(arg, s.argument(arg.Node, dot), arg.Field)
+	case *parse.PipeNode:
+		return s.pipe(arg, dot)
+	case *parse.IdentifierNode:
+		return s.function(arg)
+	}
+	return nil
+}
+
+// function checks a function identifier and returns its result type.
+func (s *scope) function(ident *parse.IdentifierNode) reflect.Type {
+	// Builtins
+	for _, builtin := range builtins {
+		if ident.Ident == builtin {
+			return nil
+		}
+	}
+	// Known functions
+	fn, ok := s.checker.Funcs[ident.Ident]
+	if !ok {
+		s.checker.report(s.tree, s.name, ident, "function %q is not defined", ident.Ident)
+		return nil
+	}
+	// Resolve result type
+	if typ := reflect.TypeOf(fn); typ != nil && typ.Kind() == reflect.Func && typ.NumOut() > 0 {
+		return typ.Out(0)
+	}
+	return nil
+}
+
+// fields resolves a chain of fields/methods from provided type.
+// Reports nonexistent ones and returns resulting type (nil if unknown).
+func (s *scope) fields(node parse.Node, typ reflect.Type, idents []string) reflect.Type {
+	for _, ident := range idents {
+		// Stop on unknown type
+		if typ == nil {
+			return nil
+		}
+		// Resolve method (on pointer, to include both receivers)
+		ptr := typ
+		if ptr.Kind() != reflect.Pointer && ptr.Kind() != reflect.Interface {
+			ptr = reflect.PointerTo(ptr)
+		}
+		if method, ok := ptr.MethodByName(ident); ok {
+			typ = result(method.Type)
+			continue
+		}
+		// Dereference
+		for typ.Kind() == reflect.Pointer {
+			typ = typ.Elem()
+		}
+		// Resolve field
+		switch typ.Kind() {
+		case reflect.Struct:
+			field, ok := typ.FieldByName(ident)
+			if !ok || !field.IsExported() {
+				s.checker.report(s.tree, s.name, node, "can't evaluate field %s in type %s", ident, typ)
+				return nil
+			}
+			typ = field.Type
+		case reflect.Map:
+			typ = typ.Elem()
+		case reflect.Interface:
+			// Dynamic type, can't be checked
+			return nil
+		default:
+			s.checker.report(s.tree, s.name, node, "can't evaluate field %s in type %s", ident, typ)
+			return nil
+		}
+	}
+	return typ
+}
+
+// result returns the first result type of the method (nil if unknown).
+func result(method reflect.Type) reflect.Type {
+	if method.NumOut() == 0 {
+		return nil
+	}
+	return method.Out(0)
+}
+
+// iteration returns key and element types of the range target.
+func iteration(typ reflect.Type) (reflect.Type, reflect.Type) {
+	if typ == nil {
+		return nil, nil
+	}
+	for typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	switch typ.Kind() {
+	case reflect.Slice, reflect.Array:
+		return reflect.TypeOf(0), typ.Elem()
+	case reflect.Map:
+		return typ.Key(), typ.Elem()
+	case reflect.Chan:
+		return typ.Elem(), typ.Elem()
+	}
+	return nil, nil
+}
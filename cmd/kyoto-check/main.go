@@ -0,0 +1,137 @@
+// Command kyoto-check statically checks project templates.
+//
+// It reports syntax errors, calls to unknown functions, undefined templates
+// and references to nonexistent fields and methods of component states.
+//
+// State types are taken from the components registry.
+// Register components with state prototypes and provide their packages with -pkg flag,
+// the command builds and runs a checking program, linked with these packages.
+// Packages are resolved from the current directory, which must be inside of the project module.
+// Components, declared in the main package, can't be loaded this way,
+// move them into a separate package or use check.Checker in a test.
+// Without -pkg flag, fields and methods are not checked.
+//
+//	var Badge = component.Register("cart.Badge", BadgeComponent, &BadgeState{})
+//
+// Usage:
+//
+//	kyoto-check [-pkg "./components/..."] [-glob "*.html"] [-funcs "foo,bar"]
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"go.kyoto.codes/v3/check"
+)
+
+// program is a checking program source template.
+const program = `package main
+
+import (
+	"os"
+
+	"go.kyoto.codes/v3/check"
+%s)
+
+func main() {
+	os.Exit(check.Main(os.Args[1:]))
+}
+`
+
+func main() {
+	// Split packages off the arguments
+	pkgs, args, err := packages(os.Args[1:])
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	// Check without state types
+	if pkgs == "" {
+		os.Exit(check.Main(args))
+	}
+	// Check with linked packages
+	code, err := linked(pkgs, args)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	os.Exit(code)
+}
+
+// packages extracts -pkg flag value from the arguments.
+// Other arguments (including unknown flags) are passed through to check.Main.
+func packages(args []string) (string, []string, error) {
+	// Define flags
+	flags := flag.NewFlagSet("kyoto-check", flag.ContinueOnError)
+	flags.SetOutput(io.Discard)
+	pkgs := flags.String("pkg", "", "Comma-separated packages with registered components")
+	// Parse, passing through unknown flags and positional arguments
+	rest := []string{}
+	for len(args) > 0 {
+		err := flags.Parse(args)
+		remaining := flags.Args()
+		if err != nil {
+			arg := args[len(args)-len(remaining)-1]
+			name, _, _ := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+			if flags.Lookup(name) != nil {
+				return "", nil, err
+			}
+			rest = append(rest, arg)
+		} else if consumed := len(args) - len(remaining); consumed > 0 && args[consumed-1] == "--" {
+			rest = append(append(rest, "--"), remaining...)
+			break
+		} else if len(remaining) > 0 {
+			rest = append(rest, remaining[0])
+			remaining = remaining[1:]
+		}
+		args = remaining
+	}
+	return *pkgs, rest, nil
+}
+
+// linked builds and runs a checking program, linked with provided packages.
+func linked(pkgs string, args []string) (int, error) {
+	// Resolve import paths of non-main packages
+	list := exec.Command("go", append([]string{"list", "-f", `{{if ne .Name "main"}}{{.ImportPath}}{{end}}`}, strings.Split(pkgs, ",")...)...)
+	list.Stderr = os.Stderr
+	out, err := list.Output()
+	if err != nil {
+		return 0, fmt.Errorf("list packages: %w", err)
+	}
+	imports := strings.Builder{}
+	for _, path := range strings.Fields(string(out)) {
+		imports.WriteString(fmt.Sprintf("\t_ %q\n", path))
+	}
+	// Write program into a temporary directory inside of the module
+	dir, err := os.MkdirTemp(".", "_kyoto_check")
+	if err != nil {
+		return 0, err
+	}
+	defer os.RemoveAll(dir)
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(fmt.Sprintf(program, imports.String())), 0o644); err != nil {
+		return 0, err
+	}
+	// Build program
+	bin := filepath.Join(dir, "kyoto-check")
+	build := exec.Command("go", "build", "-o", bin, "./"+filepath.ToSlash(dir))
+	build.Stdout, build.Stderr = os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		return 0, fmt.Errorf("build checking program: %w", err)
+	}
+	// Run program
+	run := exec.Command(bin, args...)
+	run.Stdout, run.Stderr = os.Stdout, os.Stderr
+	if err := run.Run(); err != nil {
+		if exit, ok := err.(*exec.ExitError); ok {
+			return exit.ExitCode(), nil
+		}
+		return 0, err
+	}
+	return 0, nil
+}
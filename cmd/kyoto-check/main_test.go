@@ -0,0 +1,37 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPackages(t *testing.T) {
+	tests := []struct {
+		name string
+		args []string
+		pkgs string
+		rest []string
+		err  bool
+	}{
+		{"empty", nil, "", []string{}, false},
+		{"flag", []string{"-pkg", "./components/..."}, "./components/...", []string{}, false},
+		{"double dash", []string{"--pkg=./a,./b"}, "./a,./b", []string{}, false},
+		{"pass through", []string{"-glob", "*.html", "-pkg", "./a", "-funcs=foo"}, "./a", []string{"-glob", "*.html", "-funcs=foo"}, false},
+		{"unknown", []string{"-unknown", "-pkg=./a"}, "./a", []string{"-unknown"}, false},
+		{"help", []string{"-h"}, "", []string{"-h"}, false},
+		{"bare names", []string{"pkg", "pkg=./a"}, "", []string{"pkg", "pkg=./a"}, false},
+		{"terminator", []string{"-pkg", "./a", "--", "-pkg", "./b"}, "./a", []string{"--", "-pkg", "./b"}, false},
+		{"missing value", []string{"-pkg"}, "", nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pkgs, rest, err := packages(tt.args)
+			if (err != nil) != tt.err {
+				t.Fatalf("got error %v, want error %v", err, tt.err)
+			}
+			if pkgs != tt.pkgs || !reflect.DeepEqual(rest, tt.rest) {
+				t.Errorf("got %q %q, want %q %q", pkgs, rest, tt.pkgs, tt.rest)
+			}
+		})
+	}
+}
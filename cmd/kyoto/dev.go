@@ -0,0 +1,131 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// runDev runs a development server.
+// Project is rebuilt and restarted on sources and templates changes.
+func runDev(args []string) error {
+	// Parse arguments
+	flags := flag.NewFlagSet("dev", flag.ExitOnError)
+	dir := flags.String("dir", ".", "Project directory")
+	exts := flags.String("ext", ".go,.html,.txt", "Comma-separated watched file extensions")
+	interval := flags.Duration("interval", 500*time.Millisecond, "Changes polling interval")
+	flags.Parse(args)
+	// Resolve binary path, removed on exit
+	binary := filepath.Join(os.TempDir(), fmt.Sprintf("kyoto-dev-%d", os.Getpid()))
+	defer os.Remove(binary)
+	// Handle interrupt, so deferred cleanup is done
+	interrupt := make(chan os.Signal, 1)
+	signal.Notify(interrupt, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(interrupt)
+	// Build, run and restart on changes
+	extensions := strings.Split(*exts, ",")
+	for {
+		// Build and run
+		snapshot := watch(*dir, extensions)
+		process := start(*dir, binary, flags.Args())
+		// Wait for changes, stopping on interrupt
+		for changes := false; !changes; {
+			select {
+			case <-interrupt:
+				stop(process)
+				return nil
+			case <-time.After(*interval):
+				changes = changed(snapshot, watch(*dir, extensions))
+			}
+		}
+		// Stop
+		fmt.Println("kyoto: changes detected, restarting")
+		stop(process)
+	}
+}
+
+// start builds the project and runs resulting binary.
+// Returns nil if build failed.
+func start(dir, binary string, args []string) *exec.Cmd {
+	// Build
+	build := exec.Command("go", "build", "-o", binary, ".")
+	build.Dir, build.Stdout, build.Stderr = dir, os.Stdout, os.Stderr
+	if err := build.Run(); err != nil {
+		fmt.Println("kyoto: build failed, waiting for changes")
+		return nil
+	}
+	// Run
+	run := exec.Command(binary, args...)
+	run.Dir, run.Stdout, run.Stderr = dir, os.Stdout, os.Stderr
+	if err := run.Start(); err != nil {
+		fmt.Println("kyoto: run failed:", err)
+		return nil
+	}
+	return run
+}
+
+// stop gracefully stops the process, killing it after timeout.
+func stop(process *exec.Cmd) {
+	if process == nil {
+		return
+	}
+	// Interrupt
+	done := make(chan struct{})
+	go func() {
+		process.Wait()
+		close(done)
+	}()
+	process.Process.Signal(os.Interrupt)
+	// Kill on timeout
+	select {
+	case <-done:
+	case <-time.After(3 * time.Second):
+		process.Process.Kill()
+		<-done
+	}
+}
+
+// watch returns modification times of watched files.
+func watch(dir string, extensions []string) map[string]time.Time {
+	snapshot := map[string]time.Time{}
+	filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		// Skip hidden and vendored directories
+		if d.IsDir() && path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		// Store watched files
+		for _, ext := range extensions {
+			if !d.IsDir() && strings.HasSuffix(path, strings.TrimSpace(ext)) {
+				if info, err := d.Info(); err == nil {
+					snapshot[path] = info.ModTime()
+				}
+				break
+			}
+		}
+		return nil
+	})
+	return snapshot
+}
+
+// changed compares two snapshots.
+func changed(a, b map[string]time.Time) bool {
+	if len(a) != len(b) {
+		return true
+	}
+	for path, t := range a {
+		if !b[path].Equal(t) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,40 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestWatch(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"main.go", "page.html", "README.md", ".git/config.go", "vendor/lib/lib.go", "components/badge.go"} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		os.MkdirAll(filepath.Dir(path), 0755)
+		os.WriteFile(path, nil, 0644)
+	}
+	// Only watched extensions outside of hidden and vendored directories
+	snapshot := watch(dir, []string{".go", " .html"})
+	for _, name := range []string{"main.go", "page.html", "components/badge.go"} {
+		if _, ok := snapshot[filepath.Join(dir, filepath.FromSlash(name))]; !ok {
+			t.Errorf("%s is not watched", name)
+		}
+	}
+	if len(snapshot) != 3 {
+		t.Errorf("got %d watched files, want 3: %v", len(snapshot), snapshot)
+	}
+	// Changes
+	if changed(snapshot, watch(dir, []string{".go", ".html"})) {
+		t.Error("unchanged snapshot is changed")
+	}
+	modified := time.Now().Add(time.Hour)
+	os.Chtimes(filepath.Join(dir, "main.go"), modified, modified)
+	if !changed(snapshot, watch(dir, []string{".go", ".html"})) {
+		t.Error("modification is not detected")
+	}
+	os.WriteFile(filepath.Join(dir, "cart.go"), nil, 0644)
+	if !changed(snapshot, watch(dir, []string{".go", ".html"})) {
+		t.Error("new file is not detected")
+	}
+}
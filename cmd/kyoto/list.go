@@ -0,0 +1,169 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+)
+
+// listing is a component listing entry.
+type listing struct {
+	name      string   // Component name (registered or resolved from identifier)
+	endpoints []string // Endpoints, the component is served on
+}
+
+// runList lists components and their endpoints by parsing project sources.
+// Components are discovered from component.Register calls
+// and rendering.Handler registrations on mux.
+func runList(args []string) error {
+	// Resolve directory
+	dir := "."
+	if len(args) > 0 {
+		dir = args[0]
+	}
+	// Collect components by expression
+	listings := map[string]*listing{}
+	get := func(expr string) *listing {
+		if listings[expr] == nil {
+			tokens := strings.Split(expr, ".")
+			listings[expr] = &listing{name: tokens[len(tokens)-1]}
+		}
+		return listings[expr]
+	}
+	// Parse project sources
+	err := filepath.WalkDir(dir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		// Skip hidden and vendored directories
+		if d.IsDir() && path != dir && (strings.HasPrefix(d.Name(), ".") || d.Name() == "vendor" || d.Name() == "node_modules") {
+			return filepath.SkipDir
+		}
+		// Pass non-go files
+		if d.IsDir() || !strings.HasSuffix(path, ".go") || strings.HasSuffix(path, "_test.go") {
+			return nil
+		}
+		// Parse
+		f, err := parser.ParseFile(token.NewFileSet(), path, nil, 0)
+		if err != nil {
+			return err
+		}
+		// Inspect
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.ValueSpec:
+				// var Name = component.Register("name", ...)
+				for i, value := range n.Values {
+					if name, ok := registered(value); ok && i < len(n.Names) {
+						get(n.Names[i].Name).name = name
+					}
+				}
+			case *ast.AssignStmt:
+				// Name := component.Register("name", ...)
+				for i, value := range n.Rhs {
+					if name, ok := registered(value); ok && i < len(n.Lhs) {
+						get(types.ExprString(n.Lhs[i])).name = name
+					}
+				}
+			case *ast.CallExpr:
+				// component.Register("name", Component)
+				// Inline function literals are listed by the assigned variable only
+				if name, ok := registered(n); ok && referenced(n.Args[1]) {
+					get(types.ExprString(n.Args[1])).name = name
+				}
+				// mux.HandleFunc("/path", rendering.Handler(Component))
+				if path, expr, ok := handled(n); ok {
+					l := get(expr)
+					l.endpoints = append(l.endpoints, path)
+				}
+			}
+			return true
+		})
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// Print sorted table
+	exprs := make([]string, 0, len(listings))
+	for expr := range listings {
+		exprs = append(exprs, expr)
+	}
+	sort.Strings(exprs)
+	w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(w, "COMPONENT\tNAME\tENDPOINTS")
+	for _, expr := range exprs {
+		fmt.Fprintf(w, "%s\t%s\t%s\n", expr, listings[expr].name, strings.Join(listings[expr].endpoints, ", "))
+	}
+	return w.Flush()
+}
+
+// registered checks if the expression is a component.Register call
+// and returns the registered name.
+func registered(expr ast.Expr) (string, bool) {
+	call, ok := expr.(*ast.CallExpr)
+	if !ok || !selects(call.Fun, "component", "Register") || len(call.Args) < 2 {
+		return "", false
+	}
+	return literal(call.Args[0])
+}
+
+// referenced checks if the expression is a component reference
+// (identifier or selector, f.e. Badge or cart.Badge).
+func referenced(expr ast.Expr) bool {
+	switch expr.(type) {
+	case *ast.Ident, *ast.SelectorExpr:
+		return true
+	default:
+		return false
+	}
+}
+
+// handled checks if the call is a mux handler registration with rendering.Handler
+// and returns the path with component expression.
+func handled(call *ast.CallExpr) (string, string, bool) {
+	// Check registration method
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok || (sel.Sel.Name != "HandleFunc" && sel.Sel.Name != "Handle") || len(call.Args) != 2 {
+		return "", "", false
+	}
+	path, ok := literal(call.Args[0])
+	if !ok {
+		return "", "", false
+	}
+	// Check handler
+	handler, ok := call.Args[1].(*ast.CallExpr)
+	if !ok || !selects(handler.Fun, "rendering", "Handler") || len(handler.Args) == 0 {
+		return "", "", false
+	}
+	return path, types.ExprString(handler.Args[0]), true
+}
+
+// selects checks if the expression is a package selector (f.e. component.Register).
+func selects(expr ast.Expr, pkg, name string) bool {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok || sel.Sel.Name != name {
+		return false
+	}
+	ident, ok := sel.X.(*ast.Ident)
+	return ok && ident.Name == pkg
+}
+
+// literal returns a string literal value.
+func literal(expr ast.Expr) (string, bool) {
+	lit, ok := expr.(*ast.BasicLit)
+	if !ok || lit.Kind != token.STRING {
+		return "", false
+	}
+	value, err := strconv.Unquote(lit.Value)
+	return value, err == nil
+}
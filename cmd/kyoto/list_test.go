@@ -0,0 +1,55 @@
+package main
+
+import (
+	"go/ast"
+	"go/parser"
+	"testing"
+)
+
+func TestRegistered(t *testing.T) {
+	tests := []struct {
+		expr string
+		name string
+		ok   bool
+	}{
+		{`component.Register("cart.Badge", Badge)`, "cart.Badge", true},
+		{`component.Register("cart.Badge", Badge, &BadgeState{})`, "cart.Badge", true},
+		{`component.Register(name, Badge)`, "", false},
+		{`component.Use(ctx, Badge)`, "", false},
+		{`Badge`, "", false},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if name, ok := registered(expr); name != tt.name || ok != tt.ok {
+			t.Errorf("registered(%s) = %q, %v, want %q, %v", tt.expr, name, ok, tt.name, tt.ok)
+		}
+	}
+}
+
+func TestHandled(t *testing.T) {
+	tests := []struct {
+		expr string
+		path string
+		comp string
+		ok   bool
+	}{
+		{`mux.HandleFunc("/", rendering.Handler(Page))`, "/", "Page", true},
+		{`mux.HandleFunc("/cart", rendering.Handler(cart.Page, auth))`, "/cart", "cart.Page", true},
+		{`mux.Handle("/badge", rendering.Handler(Badge))`, "/badge", "Badge", true},
+		{`mux.HandleFunc(path, rendering.Handler(Page))`, "", "", false},
+		{`mux.HandleFunc("/", handler)`, "", "", false},
+		{`http.Get("/")`, "", "", false},
+	}
+	for _, tt := range tests {
+		expr, err := parser.ParseExpr(tt.expr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if path, comp, ok := handled(expr.(*ast.CallExpr)); path != tt.path || comp != tt.comp || ok != tt.ok {
+			t.Errorf("handled(%s) = %q, %q, %v, want %q, %q, %v", tt.expr, path, comp, ok, tt.path, tt.comp, tt.ok)
+		}
+	}
+}
@@ -0,0 +1,51 @@
+// Command kyoto is a kyoto projects tool.
+//
+// Usage:
+//
+//	kyoto new <directory> [module]   Scaffold a new project
+//	kyoto component <Name> [-dir .]  Scaffold a new component (state, function and template)
+//	kyoto list [directory]           List components and their endpoints
+//	kyoto dev [-dir .] [-- args]     Run a development server, restarting on changes
+package main
+
+import (
+	"fmt"
+	"os"
+)
+
+// usage is a command usage text.
+const usage = `Usage:
+
+	kyoto new <directory> [module]   Scaffold a new project
+	kyoto component <Name> [-dir .]  Scaffold a new component (state, function and template)
+	kyoto list [directory]           List components and their endpoints
+	kyoto dev [-dir .] [-- args]     Run a development server, restarting on changes
+`
+
+func main() {
+	// Ensure command is provided
+	if len(os.Args) < 2 {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	// Dispatch
+	var err error
+	switch os.Args[1] {
+	case "new":
+		err = runNew(os.Args[2:])
+	case "component":
+		err = runComponent(os.Args[2:])
+	case "list":
+		err = runList(os.Args[2:])
+	case "dev":
+		err = runDev(os.Args[2:])
+	default:
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+	// Report error
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "kyoto:", err)
+		os.Exit(1)
+	}
+}
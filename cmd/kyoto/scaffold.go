@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"flag"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/template"
+)
+
+// Scaffolding templates.
+// Delimiters are changed to avoid conflicts with generated html templates.
+var (
+	scaffoldGoMod = `module [[ .Module ]]
+
+go 1.20
+`
+	scaffoldMain = `package main
+
+import (
+	"net/http"
+
+	"go.kyoto.codes/v3/htmx"
+	"go.kyoto.codes/v3/rendering"
+)
+
+func main() {
+	// Initialize mux
+	mux := http.NewServeMux()
+	// Register htmx distribution files
+	mux.Handle(htmx.SCRIPT_PREFIX, htmx.ScriptHandler())
+	// Register pages
+	mux.HandleFunc("/", rendering.Handler(Page))
+	// Serve
+	http.ListenAndServe(":8080", mux)
+}
+`
+	scaffoldComponent = `package [[ .Package ]]
+
+import (
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// [[ .Name ]]State is a [[ .Name ]] component state.
+type [[ .Name ]]State struct {
+	component.[[ .State ]]
+	rendering.Template
+}
+
+// [[ .Name ]] is a [[ .Name ]] component.
+func [[ .Name ]](ctx *component.Context) component.State {
+	// Initialize state
+	state := &[[ .Name ]]State{}
+	// Done
+	return state
+}
+`
+	scaffoldTemplate = `{{ define "[[ .Name ]]" }}
+<div>
+	[[ .Name ]]
+</div>
+{{ end }}
+`
+	scaffoldPageTemplate = `{{ define "Page" }}
+<html>
+	<head>
+		<title>kyoto</title>
+		{{ hxscript }}
+		{{ head . }}
+	</head>
+	<body>
+		<h1>Hello from kyoto!</h1>
+	</body>
+</html>
+{{ end }}
+`
+)
+
+// scaffoldStates holds component state implementations, available for scaffolding.
+var scaffoldStates = []string{"Universal", "Server", "Disposable"}
+
+// scaffold renders scaffolding template into a new file.
+// Existing files are never overwritten.
+func scaffold(path, text string, data any) error {
+	// Ensure file doesn't exist
+	if _, err := os.Stat(path); err == nil {
+		return fmt.Errorf("%s already exists", path)
+	}
+	// Render
+	out := &bytes.Buffer{}
+	if err := template.Must(template.New(path).Delims("[[", "]]").Parse(text)).Execute(out, data); err != nil {
+		return err
+	}
+	// Write
+	if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(path, out.Bytes(), 0644); err != nil {
+		return err
+	}
+	fmt.Println("created", path)
+	return nil
+}
+
+// runNew scaffolds a new project.
+func runNew(args []string) error {
+	// Parse arguments
+	if len(args) < 1 {
+		return errors.New("usage: kyoto new <directory> [module]")
+	}
+	dir := args[0]
+	module := filepath.Base(dir)
+	if len(args) > 1 {
+		module = args[1]
+	}
+	// Scaffold
+	files := []struct {
+		name string
+		text string
+		data any
+	}{
+		{"go.mod", scaffoldGoMod, map[string]string{"Module": module}},
+		{"main.go", scaffoldMain, nil},
+		{"page.go", scaffoldComponent, map[string]string{"Package": "main", "Name": "Page", "State": "Disposable"}},
+		{"page.html", scaffoldPageTemplate, nil},
+	}
+	for _, file := range files {
+		if err := scaffold(filepath.Join(dir, file.name), file.text, file.data); err != nil {
+			return err
+		}
+	}
+	// Print next steps
+	fmt.Printf("\nNext steps:\n\n\tcd %s\n\tgo get go.kyoto.codes/v3\n\tkyoto dev\n", dir)
+	return nil
+}
+
+// runComponent scaffolds a new component.
+func runComponent(args []string) error {
+	// Parse arguments
+	flags := flag.NewFlagSet("component", flag.ExitOnError)
+	dir := flags.String("dir", ".", "Component directory")
+	state := flags.String("state", "Universal", "Component state implementation ("+strings.Join(scaffoldStates, ", ")+")")
+	if len(args) < 1 || strings.HasPrefix(args[0], "-") {
+		return errors.New("usage: kyoto component <Name> [-dir .] [-state Universal]")
+	}
+	name := args[0]
+	flags.Parse(args[1:])
+	// Validate name
+	if !token.IsIdentifier(name) || !token.IsExported(name) {
+		return fmt.Errorf("component name %q must be an exported Go identifier (f.e. Counter)", name)
+	}
+	// Validate state
+	known := false
+	for _, s := range scaffoldStates {
+		known = known || s == *state
+	}
+	if !known {
+		return fmt.Errorf("component state %q must be one of %s", *state, strings.Join(scaffoldStates, ", "))
+	}
+	// Scaffold
+	data := map[string]string{"Package": packageName(*dir), "Name": name, "State": *state}
+	base := filepath.Join(*dir, strings.ToLower(name))
+	if err := scaffold(base+".go", scaffoldComponent, data); err != nil {
+		return err
+	}
+	return scaffold(base+".html", scaffoldTemplate, data)
+}
+
+// packageName resolves Go package name of the directory ("main" by default).
+func packageName(dir string) string {
+	files, _ := filepath.Glob(filepath.Join(dir, "*.go"))
+	for _, file := range files {
+		if strings.HasSuffix(file, "_test.go") {
+			continue
+		}
+		if f, err := parser.ParseFile(token.NewFileSet(), file, nil, parser.PackageClauseOnly); err == nil {
+			return f.Name.Name
+		}
+	}
+	return "main"
+}
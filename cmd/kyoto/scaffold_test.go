@@ -0,0 +1,94 @@
+package main
+
+import (
+	"go/parser"
+	"go/token"
+	"html/template"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"go.kyoto.codes/v3/rendering"
+)
+
+func TestRunNew(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "shop")
+	if err := runNew([]string{dir, "example.com/shop"}); err != nil {
+		t.Fatal(err)
+	}
+	// Sources are valid
+	for _, name := range []string{"main.go", "page.go"} {
+		if _, err := parser.ParseFile(token.NewFileSet(), filepath.Join(dir, name), nil, 0); err != nil {
+			t.Errorf("%s is not valid: %v", name, err)
+		}
+	}
+	if mod, _ := os.ReadFile(filepath.Join(dir, "go.mod")); !strings.HasPrefix(string(mod), "module example.com/shop\n") {
+		t.Errorf("go.mod is %q", mod)
+	}
+	// Page template is valid and uses the pinned htmx script
+	page, err := os.ReadFile(filepath.Join(dir, "page.html"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := template.New("").Funcs(rendering.FuncMapAll).Parse(string(page)); err != nil {
+		t.Errorf("page.html is not valid: %v", err)
+	}
+	if !strings.Contains(string(page), "{{ hxscript }}") {
+		t.Error("page.html doesn't use hxscript")
+	}
+	// Existing project is not overwritten
+	if err := runNew([]string{dir}); err == nil {
+		t.Error("existing files are overwritten")
+	}
+}
+
+func TestRunComponent(t *testing.T) {
+	tests := []struct {
+		name  string
+		args  []string
+		state string
+		err   string
+	}{
+		{"default state", []string{"Counter"}, "component.Universal", ""},
+		{"state", []string{"Badge", "-state", "Disposable"}, "component.Disposable", ""},
+		{"unknown state", []string{"Badge", "-state", "Universl"}, "", `component state "Universl" must be one of`},
+		{"unexported name", []string{"counter"}, "", "must be an exported Go identifier"},
+		{"missing name", []string{"-state", "Server"}, "", "usage"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			err := runComponent(append(tt.args, "-dir", dir))
+			if tt.err != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.err) {
+					t.Errorf("got error %v, want %q", err, tt.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			base := filepath.Join(dir, strings.ToLower(tt.args[0]))
+			source, _ := os.ReadFile(base + ".go")
+			if !strings.Contains(string(source), "\t"+tt.state+"\n") {
+				t.Errorf("component doesn't embed %s:\n%s", tt.state, source)
+			}
+			if _, err := os.Stat(base + ".html"); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestPackageName(t *testing.T) {
+	dir := t.TempDir()
+	if name := packageName(dir); name != "main" {
+		t.Errorf("empty directory package is %q, want main", name)
+	}
+	os.WriteFile(filepath.Join(dir, "cart_test.go"), []byte("package cart_test\n"), 0644)
+	os.WriteFile(filepath.Join(dir, "cart.go"), []byte("package cart\n"), 0644)
+	if name := packageName(dir); name != "cart" {
+		t.Errorf("package is %q, want cart", name)
+	}
+}
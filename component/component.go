@@ -9,9 +9,15 @@ import (
 // Component represents a component state builder, defined as a function.
 type Component func(ctx *Context) State
 
-// GetName returns the name of the component,
-// based on the function name.
+// GetName returns the name of the component.
+// Registered name is used if the component was registered (check Register),
+// otherwise name is based on the function name.
 func (c Component) GetName() string {
+	// Registered name
+	if name, ok := NameOf(c); ok {
+		return name
+	}
+	// Function name
 	functionPath := runtime.FuncForPC(reflect.ValueOf(c).Pointer()).Name()
 	tokens := strings.Split(functionPath, ".")
 	if strings.HasPrefix(tokens[len(tokens)-1], "func") {
@@ -2,6 +2,7 @@ package component
 
 import (
 	"net/http"
+	"sync"
 )
 
 // Context is the context of the current request.
@@ -12,6 +13,20 @@ type Context struct {
 	Request        *http.Request
 	// Store
 	Store
+	// Page head elements
+	Head *Head
+	// Request locale (f.e. "en", "de-AT"), empty by default
+	Locale string
+	// Authenticated principal, set by Guard (nil by default)
+	Principal any
+
+	// Response status
+	mu     sync.Mutex
+	status int
+	// Streaming (nil by default)
+	stream Stream
+	// Futures tracking
+	waiters []func()
 }
 
 // Initialize a new context, that will be passed through the components.
@@ -21,9 +36,65 @@ func NewContext(w http.ResponseWriter, r *http.Request) *Context {
 		ResponseWriter: w,
 		Request:        r,
 		Store:          NewMapStore(),
+		Head:           NewHead(),
+	}
+}
+
+// SetStatus sets a response status code (f.e. 404, 422).
+// Status is written right before the output,
+// so in buffered rendering mode it may be set by nested components too.
+func (ctx *Context) SetStatus(status int) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.status = status
+}
+
+// Status returns a response status code, set with SetStatus (zero if not set).
+func (ctx *Context) Status() int {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.status
+}
+
+// SetStream provides the context with a stream.
+// It's safe to call while components are being built.
+func (ctx *Context) SetStream(stream Stream) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.stream = stream
+}
+
+// GetStream returns the context stream (nil if not streaming).
+func (ctx *Context) GetStream() Stream {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	return ctx.stream
+}
+
+// Wait awaits for all futures, created within the context
+// (including ones, created during awaiting).
+func (ctx *Context) Wait() {
+	for i := 0; ; i++ {
+		// Get next waiter
+		ctx.mu.Lock()
+		if i >= len(ctx.waiters) {
+			ctx.mu.Unlock()
+			return
+		}
+		wait := ctx.waiters[i]
+		ctx.mu.Unlock()
+		// Wait
+		wait()
 	}
 }
 
+// track registers a future waiter.
+func (ctx *Context) track(wait func()) {
+	ctx.mu.Lock()
+	defer ctx.mu.Unlock()
+	ctx.waiters = append(ctx.waiters, wait)
+}
+
 // Store allows you to store own data inside of the context.
 type Store interface {
 	Get(key string) any
@@ -0,0 +1,35 @@
+package component
+
+// Contextual implements component context getter/setter.
+// It binds the state to the context it was built within,
+// so context is reachable having a state only (f.e. in template functions).
+// Context is not a part of marshaled state.
+type Contextual struct {
+	ctx *Context
+}
+
+// SetContext is a component context setter.
+func (c *Contextual) SetContext(ctx *Context) {
+	c.ctx = ctx
+}
+
+// GetContext is a component context getter.
+func (c *Contextual) GetContext() *Context {
+	return c.ctx
+}
+
+// ContextOf returns a context, the state is bound to.
+// Returns nil if state doesn't support binding or wasn't bound yet.
+func ContextOf(state State) *Context {
+	if c, ok := state.(interface{ GetContext() *Context }); ok {
+		return c.GetContext()
+	}
+	return nil
+}
+
+// Bind binds the state to the context, if state supports binding.
+func Bind(ctx *Context, state State) {
+	if c, ok := state.(interface{ SetContext(ctx *Context) }); ok {
+		c.SetContext(ctx)
+	}
+}
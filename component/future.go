@@ -1,6 +1,31 @@
 package component
 
+import (
+	"encoding/json"
+	"unsafe"
+)
+
 // Future is a component state getter.
 // Under the hood it waits for async.Future,
 // gets resulting state and completes it with metadata.
 type Future func() State
+
+// MarshalJSON awaits for the future and marshals resulting state.
+// It allows to serialize states with nested components.
+// Nil future is marshaled as null.
+//
+// Note, it applies to any JSON encoding of the state, not only JSON rendering.
+// So marshaling a state (f.e. Universal state in hx-state) awaits its futures
+// and includes nested states. Tag future fields with `json:"-"` to exclude them.
+func (f Future) MarshalJSON() ([]byte, error) {
+	if f == nil {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f())
+}
+
+// key returns an identity of the future closure.
+// Funcs are not comparable, so closure pointer is used.
+func (f Future) key() uintptr {
+	return *(*uintptr)(unsafe.Pointer(&f))
+}
@@ -0,0 +1,35 @@
+package component
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+type futureState struct {
+	Disposable
+
+	Title string
+	Child Future
+}
+
+func TestFutureMarshalJSON(t *testing.T) {
+	tests := []struct {
+		name  string
+		state *futureState
+		want  string
+	}{
+		{"nil", &futureState{Title: "page"}, `{"Title":"page","Child":null}`},
+		{"resolved", &futureState{Title: "page", Child: func() State { return &futureState{Title: "child"} }}, `{"Title":"page","Child":{"Title":"child","Child":null}}`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := json.Marshal(tt.state)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(out) != tt.want {
+				t.Errorf("got %s, want %s", out, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,120 @@
+package component
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"go.kyoto.codes/zen/v3/logic"
+)
+
+// ErrForbidden is a generic policy error, denying the access.
+var ErrForbidden = errors.New("forbidden")
+
+// GUARD_FORBIDDEN writes a response for denied requests.
+// Receives a policy error.
+// Override it to render your own 403 page.
+var GUARD_FORBIDDEN = func(ctx *Context, err error) {
+	http.Error(ctx.ResponseWriter, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+}
+
+// Policy checks if the request is allowed
+// and returns an authenticated principal (f.e. user).
+// Return *Redirect error to redirect the client (f.e. to the login page),
+// any other error denies the access with 403.
+type Policy func(ctx *Context) (principal any, err error)
+
+// Redirect is a policy error, which redirects the client.
+type Redirect struct {
+	URL    string
+	Status int // http.StatusFound by default
+}
+
+// Error implements error interface.
+func (r *Redirect) Error() string {
+	return "redirect to " + r.URL
+}
+
+// Denied is a state of a guarded component, which policy denied the access.
+// Response is already written by the guard, so rendering is skipped.
+type Denied struct {
+	Disposable
+
+	Err error
+}
+
+// RenderSkip implements rendering.Renderer, always skipping the rendering.
+func (*Denied) RenderSkip() bool {
+	return true
+}
+
+// Render implements rendering.Renderer, doing nothing.
+func (*Denied) Render(state State, out io.Writer) error {
+	return nil
+}
+
+// Guard wraps the component with access policies.
+// Policies are checked in order before the component function,
+// principal, returned by the last policy, is exposed as ctx.Principal.
+// Guarded component is executed for htmx actions as well,
+// so actions are protected too.
+//
+// Denied requests are responded right away,
+// with a redirect (HX-Redirect header for htmx requests) or with GUARD_FORBIDDEN.
+// Responses are written directly, so guards are meant for pages and component endpoints,
+// not for nested components of an already rendering page.
+//
+//	var Dashboard = component.Guard(DashboardPage, func(ctx *component.Context) (any, error) {
+//		user := auth.User(ctx.Request)
+//		if user == nil {
+//			return nil, &component.Redirect{URL: "/login"}
+//		}
+//		return user, nil
+//	})
+func Guard(c Component, policies ...Policy) Component {
+	return func(ctx *Context) State {
+		// Check policies
+		for _, policy := range policies {
+			principal, err := policy(ctx)
+			if err != nil {
+				deny(ctx, err)
+				state := &Denied{Err: err}
+				state.SetName(c.GetName())
+				return state
+			}
+			ctx.Principal = principal
+		}
+		// Build state
+		state := c(ctx)
+		// Set component name, unless it's already set
+		if state.GetName() == "" {
+			state.SetName(c.GetName())
+		}
+		return state
+	}
+}
+
+// PrincipalOf returns the context principal of the expected type.
+func PrincipalOf[T any](ctx *Context) (T, bool) {
+	principal, ok := ctx.Principal.(T)
+	return principal, ok
+}
+
+// deny writes a response for denied request.
+func deny(ctx *Context, err error) {
+	// Forbid, if not a redirect
+	var redirect *Redirect
+	if !errors.As(err, &redirect) {
+		GUARD_FORBIDDEN(ctx, err)
+		return
+	}
+	// Redirect htmx request with a header.
+	// We're checking the header directly, htmx package depends on component.
+	if ctx.Request.Header.Get("HX-Request") == "true" {
+		ctx.ResponseWriter.Header().Set("HX-Redirect", redirect.URL)
+		ctx.ResponseWriter.WriteHeader(http.StatusOK)
+		return
+	}
+	// Redirect regular request
+	http.Redirect(ctx.ResponseWriter, ctx.Request, redirect.URL, logic.Or(redirect.Status, http.StatusFound))
+}
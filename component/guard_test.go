@@ -0,0 +1,118 @@
+package component
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+type guardUser struct {
+	Admin bool
+}
+
+type guardState struct {
+	Disposable
+
+	User *guardUser
+}
+
+func guardPage(ctx *Context) State {
+	user, _ := PrincipalOf[*guardUser](ctx)
+	return &guardState{User: user}
+}
+
+// guardAuthenticated takes the user from the test header.
+func guardAuthenticated(ctx *Context) (any, error) {
+	switch ctx.Request.Header.Get("X-User") {
+	case "admin":
+		return &guardUser{Admin: true}, nil
+	case "user":
+		return &guardUser{}, nil
+	}
+	return nil, &Redirect{URL: "/login"}
+}
+
+func guardAdmin(ctx *Context) (any, error) {
+	user, ok := PrincipalOf[*guardUser](ctx)
+	if !ok || !user.Admin {
+		return nil, ErrForbidden
+	}
+	return user, nil
+}
+
+func TestGuard(t *testing.T) {
+	page := Guard(guardPage, guardAuthenticated, guardAdmin)
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		denied   error
+		status   int
+		location string
+		redirect string
+	}{
+		{"anonymous", nil, &Redirect{}, http.StatusFound, "/login", ""},
+		{"anonymous htmx", map[string]string{"HX-Request": "true"}, &Redirect{}, http.StatusOK, "", "/login"},
+		{"user", map[string]string{"X-User": "user"}, ErrForbidden, http.StatusForbidden, "", ""},
+		{"admin", map[string]string{"X-User": "admin"}, nil, http.StatusOK, "", ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/admin", nil)
+			for k, v := range tt.headers {
+				r.Header.Set(k, v)
+			}
+			w := httptest.NewRecorder()
+			ctx := NewContext(w, r)
+			state := page(ctx)
+			// State
+			if tt.denied != nil {
+				denied, ok := state.(*Denied)
+				if !ok {
+					t.Fatalf("state is %T, expected *Denied", state)
+				}
+				if _, redirect := tt.denied.(*Redirect); redirect {
+					var target *Redirect
+					if !errors.As(denied.Err, &target) {
+						t.Errorf("error is %v, expected redirect", denied.Err)
+					}
+				} else if !errors.Is(denied.Err, tt.denied) {
+					t.Errorf("error is %v, expected %v", denied.Err, tt.denied)
+				}
+			} else {
+				s, ok := state.(*guardState)
+				if !ok {
+					t.Fatalf("state is %T, expected *guardState", state)
+				}
+				if s.User == nil || !s.User.Admin {
+					t.Errorf("principal is %+v, expected admin", s.User)
+				}
+			}
+			// Response
+			if w.Code != tt.status {
+				t.Errorf("status %d, expected %d", w.Code, tt.status)
+			}
+			if got := w.Header().Get("Location"); got != tt.location {
+				t.Errorf("Location %q, expected %q", got, tt.location)
+			}
+			if got := w.Header().Get("HX-Redirect"); got != tt.redirect {
+				t.Errorf("HX-Redirect %q, expected %q", got, tt.redirect)
+			}
+		})
+	}
+}
+
+func TestPrincipalOf(t *testing.T) {
+	ctx := NewContext(nil, nil)
+	if _, ok := PrincipalOf[*guardUser](ctx); ok {
+		t.Error("nil principal is resolved")
+	}
+	ctx.Principal = "user"
+	if _, ok := PrincipalOf[*guardUser](ctx); ok {
+		t.Error("principal of another type is resolved")
+	}
+	ctx.Principal = &guardUser{Admin: true}
+	if user, ok := PrincipalOf[*guardUser](ctx); !ok || !user.Admin {
+		t.Errorf("PrincipalOf = %+v, %v", user, ok)
+	}
+}
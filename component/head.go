@@ -0,0 +1,88 @@
+package component
+
+import (
+	"fmt"
+	"html/template"
+	"strings"
+	"sync"
+)
+
+// Head collects page head elements (title, meta tags, links, scripts),
+// contributed by the components during their execution.
+// Elements are deduplicated by their identity (f.e. meta name),
+// later contribution overrides the earlier one.
+// It's safe for concurrent use.
+type Head struct {
+	mu       sync.Mutex
+	keys     []string
+	elements map[string]string
+}
+
+// NewHead initializes a new head collector.
+func NewHead() *Head {
+	return &Head{
+		elements: map[string]string{},
+	}
+}
+
+// set stores an element by key, keeping the order of first appearance.
+func (h *Head) set(key, element string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if _, ok := h.elements[key]; !ok {
+		h.keys = append(h.keys, key)
+	}
+	h.elements[key] = element
+}
+
+// Title sets a page title.
+func (h *Head) Title(title string) {
+	h.set("title", fmt.Sprintf(`<title>%s</title>`, template.HTMLEscapeString(title)))
+}
+
+// Meta sets a meta tag with name attribute (f.e. description).
+func (h *Head) Meta(name, content string) {
+	h.set("meta:name:"+name, fmt.Sprintf(
+		`<meta name="%s" content="%s">`,
+		template.HTMLEscapeString(name), template.HTMLEscapeString(content)))
+}
+
+// Property sets a meta tag with property attribute (f.e. OpenGraph og:title).
+func (h *Head) Property(property, content string) {
+	h.set("meta:property:"+property, fmt.Sprintf(
+		`<meta property="%s" content="%s">`,
+		template.HTMLEscapeString(property), template.HTMLEscapeString(content)))
+}
+
+// Canonical sets a canonical link.
+func (h *Head) Canonical(href string) {
+	h.set("link:canonical", fmt.Sprintf(`<link rel="canonical" href="%s">`, template.HTMLEscapeString(href)))
+}
+
+// Link adds a link tag (f.e. preload, icon).
+func (h *Head) Link(rel, href string) {
+	h.set("link:"+rel+":"+href, fmt.Sprintf(
+		`<link rel="%s" href="%s">`,
+		template.HTMLEscapeString(rel), template.HTMLEscapeString(href)))
+}
+
+// Stylesheet adds a stylesheet link.
+func (h *Head) Stylesheet(href string) {
+	h.Link("stylesheet", href)
+}
+
+// Script adds a deferred script.
+func (h *Head) Script(src string) {
+	h.set("script:"+src, fmt.Sprintf(`<script src="%s" defer></script>`, template.HTMLEscapeString(src)))
+}
+
+// Render returns collected elements markup.
+func (h *Head) Render() template.HTML {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	elements := make([]string, 0, len(h.keys))
+	for _, key := range h.keys {
+		elements = append(elements, h.elements[key])
+	}
+	return template.HTML(strings.Join(elements, "\n"))
+}
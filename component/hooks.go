@@ -0,0 +1,63 @@
+package component
+
+// Lifecycle hooks are optional interfaces, which state may implement.
+// Hooks are invoked in this order:
+//
+//   - Restore: by htmx.Post, right after the state was unmarshaled and before the action handler.
+//   - Init: right after the component function returned the state
+//     (by rendering.Handler for pages, by Use for nested components).
+//   - BeforeRender: right before the state is rendered
+//     (by rendering.Handler for pages, by `render` function for nested components).
+//     Nested component futures may be awaited here, f.e. to compute totals from children.
+//   - AfterRender: right after the state was rendered, by the same callers as BeforeRender.
+//
+// Nested components rendered with `template` call instead of `render` function
+// don't get render hooks invoked.
+
+// Restorer is a state, that has to be notified when restored from the client.
+type Restorer interface {
+	Restore(ctx *Context)
+}
+
+// Initializer is a state, that has to be initialized after building.
+type Initializer interface {
+	Init(ctx *Context)
+}
+
+// BeforeRenderer is a state, that has to be notified right before rendering.
+type BeforeRenderer interface {
+	BeforeRender(ctx *Context)
+}
+
+// AfterRenderer is a state, that has to be notified right after rendering.
+type AfterRenderer interface {
+	AfterRender(ctx *Context)
+}
+
+// Restore invokes state Restore hook, if implemented.
+func Restore(ctx *Context, state State) {
+	if h, ok := state.(Restorer); ok {
+		h.Restore(ctx)
+	}
+}
+
+// Init invokes state Init hook, if implemented.
+func Init(ctx *Context, state State) {
+	if h, ok := state.(Initializer); ok {
+		h.Init(ctx)
+	}
+}
+
+// BeforeRender invokes state BeforeRender hook, if implemented.
+func BeforeRender(ctx *Context, state State) {
+	if h, ok := state.(BeforeRenderer); ok {
+		h.BeforeRender(ctx)
+	}
+}
+
+// AfterRender invokes state AfterRender hook, if implemented.
+func AfterRender(ctx *Context, state State) {
+	if h, ok := state.(AfterRenderer); ok {
+		h.AfterRender(ctx)
+	}
+}
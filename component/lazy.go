@@ -0,0 +1,31 @@
+package component
+
+import (
+	"html/template"
+)
+
+// Lazy is a placeholder state of a lazy component.
+// Instead of computing the component during the page request,
+// it's rendered as an element, which loads the component
+// from its endpoint with htmx.
+type Lazy struct {
+	Disposable
+
+	Endpoint string        // Component endpoint, registered with rendering.Handler
+	Trigger  string        // htmx trigger ("load" by default, use "revealed" to load on scroll)
+	Loading  template.HTML // Markup to show until the component is loaded (empty by default)
+}
+
+// UseLazy allows you to use your components in lazy way.
+// Component is not executed during the page request at all,
+// it's loaded by the client from provided endpoint instead.
+func UseLazy(component Component, lazy Lazy) Future {
+	return func() State {
+		// Copy lazy parameters.
+		state := lazy
+		// Set component name.
+		state.SetName(component.GetName())
+		// Return state.
+		return &state
+	}
+}
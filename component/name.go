@@ -2,8 +2,9 @@ package component
 
 // Name implements component name getter/setter,
 // required for each component type.
+// Name is not serialized, it's set from the component on each use.
 type Name struct {
-	Name string
+	Name string `json:"-"`
 }
 
 // SetName is a component name setter.
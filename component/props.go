@@ -0,0 +1,95 @@
+package component
+
+// Props is a component with typed properties.
+// It's an alternative to wrapping a component function with a closure,
+// which provides explicit naming, default properties
+// and properties round trip for htmx endpoint calls.
+type Props[P any] struct {
+	Name     string                            // Explicit component name
+	Default  P                                 // Default properties
+	Function func(ctx *Context, props P) State // Component function
+}
+
+// Of creates a component with typed properties.
+// Panics if the name is empty.
+func Of[P any](name string, fn func(ctx *Context, props P) State) *Props[P] {
+	if name == "" {
+		panic("component name must not be empty")
+	}
+	return &Props[P]{
+		Name:     name,
+		Function: fn,
+	}
+}
+
+// WithDefault sets default properties,
+// used by endpoint when properties are not provided or partially provided.
+func (p *Props[P]) WithDefault(props P) *Props[P] {
+	p.Default = props
+	return p
+}
+
+// Register registers the endpoint component (check Endpoint) with the component name,
+// so it's available with Lookup and its state type is known to templates checking.
+// Components, returned by With and Endpoint, are closures, so they can't be
+// registered with Register and NameOf doesn't resolve them.
+// Their states are named explicitly instead.
+//
+//	var Card = component.Of("cards.Card", CardComponent).Register(&CardState{})
+func (p *Props[P]) Register(prototype ...State) *Props[P] {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	register(p.Name, p.Endpoint(), prototype)
+	return p
+}
+
+// With returns a component, bound to provided properties.
+func (p *Props[P]) With(props P) Component {
+	return func(ctx *Context) State {
+		return p.build(ctx, props)
+	}
+}
+
+// Use is a shortcut for using component with provided properties in asynchronous way.
+func (p *Props[P]) Use(ctx *Context, props P) Future {
+	return Use(ctx, p.With(props))
+}
+
+// Endpoint returns a component, that resolves properties from the request.
+// Properties are expected to be marshaled into "hx-props" request value
+// (check htmx "hxprops" function), applied on top of default properties.
+func (p *Props[P]) Endpoint() Component {
+	return func(ctx *Context) State {
+		// Start with defaults
+		props := p.Default
+		// Unmarshal provided properties, if any
+		if value := ctx.Request.FormValue("hx-props"); value != "" {
+			(&Universal{}).Unmarshal(&props, value)
+		}
+		// Build
+		return p.build(ctx, props)
+	}
+}
+
+// Marshal properties into string representation,
+// that can be passed as "hx-props" value.
+func (p *Props[P]) Marshal(props P) string {
+	return (&Universal{}).Marshal(props)
+}
+
+// build executes component function and injects component name.
+// Panics if the name is empty (f.e. Props is created without Of).
+func (p *Props[P]) build(ctx *Context, props P) State {
+	// Ensure name is provided
+	if p.Name == "" {
+		panic("component name must not be empty")
+	}
+	// Build state
+	state := p.Function(ctx, props)
+	// Inject component name, unless it's already set
+	if state.GetName() == "" {
+		state.SetName(p.Name)
+	}
+	// Return
+	return state
+}
@@ -0,0 +1,66 @@
+package component
+
+import (
+	"reflect"
+	"testing"
+)
+
+type propsState struct {
+	Disposable
+
+	Title string
+}
+
+type propsCard struct {
+	Title string
+}
+
+var propsCardComponent = Of("props.Card", func(ctx *Context, props propsCard) State {
+	return &propsState{Title: props.Title}
+}).WithDefault(propsCard{Title: "Untitled"}).Register(&propsState{})
+
+var propsOtherComponent = Of("props.Other", func(ctx *Context, props propsCard) State {
+	return &propsState{}
+}).Register()
+
+func TestPropsRegister(t *testing.T) {
+	// Bound state is named
+	state := propsCardComponent.Use(NewContext(nil, nil), propsCard{Title: "Hello"})().(*propsState)
+	if state.GetName() != "props.Card" || state.Title != "Hello" {
+		t.Errorf("got state %q %q", state.GetName(), state.Title)
+	}
+	// Endpoint is registered by name with the state type
+	if _, ok := Lookup("props.Card"); !ok {
+		t.Error("endpoint is not registered")
+	}
+	if typ, ok := StateType("props.Card"); !ok || typ != reflect.TypeOf(&propsState{}) {
+		t.Errorf("state type is %v", typ)
+	}
+	// Another props component of the same type is registered too
+	if _, ok := Lookup("props.Other"); !ok {
+		t.Error("another endpoint is not registered")
+	}
+}
+
+func TestPropsName(t *testing.T) {
+	tests := map[string]func(){
+		"empty name": func() { Of("", func(ctx *Context, props propsCard) State { return &propsState{} }) },
+		"literal":    func() { (&Props[propsCard]{}).With(propsCard{})(NewContext(nil, nil)) },
+		"duplicate name": func() {
+			Of("props.Card", func(ctx *Context, props propsCard) State { return &propsState{} }).Register()
+		},
+		"registered name": func() {
+			Of("registry.Badge", func(ctx *Context, props propsCard) State { return &propsState{} }).Register()
+		},
+	}
+	for name, f := range tests {
+		t.Run(name, func(t *testing.T) {
+			defer func() {
+				if recover() == nil {
+					t.Error("doesn't panic")
+				}
+			}()
+			f()
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package component
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+)
+
+// registry holds explicitly named components.
+var registry = struct {
+	mu       sync.RWMutex
+	names    map[string]Component
+	pointers map[uintptr]string
+	types    map[string]reflect.Type
+}{
+	names:    map[string]Component{},
+	pointers: map[uintptr]string{},
+	types:    map[string]reflect.Type{},
+}
+
+// Register registers a component with an explicit name.
+// Use package-qualified names (f.e. "cart.Badge") to avoid collisions across packages.
+// Registered name takes precedence over the name, resolved from the function name,
+// so it's used for component states and template names.
+// Panics if the name is already registered.
+//
+// Components are identified by function pointer, so the same function
+// can't be registered twice. Closure instances of the same function literal
+// are sharing the pointer, register a distinct function for each name instead.
+// Returned component is the provided one, so name is resolved both ways.
+//
+// Optionally, provide a state prototype to register the state type
+// alongside the name. It's used by static templates checking (check package).
+//
+//	var Badge = component.Register("cart.Badge", BadgeComponent, &BadgeState{})
+func Register(name string, c Component, prototype ...State) Component {
+	registry.mu.Lock()
+	defer registry.mu.Unlock()
+	// Validate pointer
+	pointer := reflect.ValueOf(c).Pointer()
+	if registered, ok := registry.pointers[pointer]; ok {
+		panic(fmt.Sprintf("component %s is already registered as %s (closure instances can't be distinguished)", name, registered))
+	}
+	// Register both ways
+	register(name, c, prototype)
+	registry.pointers[pointer] = name
+	// Return
+	return c
+}
+
+// register registers a component by name only, with optional state prototype.
+// Registry lock must be held.
+func register(name string, c Component, prototype []State) {
+	// Validate
+	if name == "" {
+		panic("component name must not be empty")
+	}
+	if _, ok := registry.names[name]; ok {
+		panic(fmt.Sprintf("component %s is already registered", name))
+	}
+	// Register
+	registry.names[name] = c
+	if len(prototype) > 0 {
+		registry.types[name] = reflect.TypeOf(prototype[0])
+	}
+}
+
+// Lookup returns a registered component by name.
+func Lookup(name string) (Component, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	c, ok := registry.names[name]
+	return c, ok
+}
+
+// NameOf returns a registered name of the component.
+func NameOf(c Component) (string, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	name, ok := registry.pointers[reflect.ValueOf(c).Pointer()]
+	return name, ok
+}
+
+// StateType returns a registered state type of the component by name.
+func StateType(name string) (reflect.Type, bool) {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	t, ok := registry.types[name]
+	return t, ok
+}
+
+// Registered returns a sorted list of registered component names.
+func Registered() []string {
+	registry.mu.RLock()
+	defer registry.mu.RUnlock()
+	names := make([]string, 0, len(registry.names))
+	for name := range registry.names {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,55 @@
+package component
+
+import "testing"
+
+type registryState struct {
+	Disposable
+}
+
+var registryBadge = Register("registry.Badge", func(ctx *Context) State {
+	return &registryState{}
+})
+
+func TestRegistryNames(t *testing.T) {
+	// Registered value
+	if name, ok := NameOf(registryBadge); !ok || name != "registry.Badge" {
+		t.Errorf("NameOf(registered) = %q, %v", name, ok)
+	}
+	if name := registryBadge.GetName(); name != "registry.Badge" {
+		t.Errorf("GetName() = %q", name)
+	}
+	// Looked up value
+	c, ok := Lookup("registry.Badge")
+	if !ok {
+		t.Fatal("Lookup failed")
+	}
+	if name, ok := NameOf(c); !ok || name != "registry.Badge" {
+		t.Errorf("NameOf(looked up) = %q, %v", name, ok)
+	}
+	// State name
+	if name := Use(NewContext(nil, nil), c)().GetName(); name != "registry.Badge" {
+		t.Errorf("state name = %q", name)
+	}
+}
+
+func TestRegistryCollisions(t *testing.T) {
+	// Closure instances are sharing the function pointer
+	factory := func() Component {
+		return func(ctx *Context) State { return &registryState{} }
+	}
+	Register("registry.First", factory())
+	tests := map[string]Component{
+		"registry.First":  func(ctx *Context) State { return &registryState{} },
+		"registry.Second": factory(),
+	}
+	for name, c := range tests {
+		func() {
+			defer func() {
+				if recover() == nil {
+					t.Errorf("Register(%q) doesn't panic", name)
+				}
+			}()
+			Register(name, c)
+		}()
+	}
+}
@@ -5,6 +5,7 @@ package component
 // On action call you'll get explicit error about wrong usage.
 type Disposable struct {
 	Name
+	Contextual
 }
 
 // Marshal for disposable returns "disposable" string.
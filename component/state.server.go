@@ -18,6 +18,7 @@ import (
 // Please, make sure this strategy actually fits to your environment.
 type Server struct {
 	Name
+	Contextual
 
 	Path    string        // Path to store component state (default "/tmp/")
 	Timeout time.Duration // State timeout (default 24 hours, clean up running on each unmarshal)
@@ -14,6 +14,7 @@ import (
 // to marshal and unmarshal the state.
 type Universal struct {
 	Name
+	Contextual
 }
 
 func (*Universal) Marshal(src any) string {
@@ -0,0 +1,57 @@
+package component
+
+import "sync"
+
+// Stream defines deferred futures resolution.
+// When context is provided with a stream,
+// futures that are not resolved yet at the moment of rendering
+// are passed to the stream instead of blocking the rendering (check Defer).
+type Stream interface {
+	// Defer takes a future and returns a placeholder state,
+	// which is rendered instead of a not resolved component.
+	// Implementation must call placeholder Resolve
+	// with the resolved state before rendering it.
+	Defer(f Future) State
+}
+
+// Defer resolves the future for rendering.
+// In case of streaming context, future created with Use and not resolved yet
+// is deferred to the context stream, and a placeholder state is returned instead.
+// It's used by `render` function, other code gets resolved states by calling futures directly.
+func Defer(f Future) State {
+	if entry, ok := unresolved.Load(f.key()); ok {
+		if stream := entry.(*deferrable).ctx.GetStream(); stream != nil {
+			return stream.Defer(f)
+		}
+	}
+	return f()
+}
+
+// Pending is a placeholder state of a deferred future.
+// Actual component is rendered later, when the future is resolved.
+type Pending struct {
+	Disposable
+
+	ID string // Deferred future identifier, unique within a stream
+
+	mu      sync.Mutex
+	prepare []func(state State)
+}
+
+// Prepare registers a function, which is applied
+// to the resolved state right before rendering (f.e. to fill slots).
+func (p *Pending) Prepare(fn func(state State)) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.prepare = append(p.prepare, fn)
+}
+
+// Resolve applies registered prepare functions to the resolved state.
+func (p *Pending) Resolve(state State) {
+	p.mu.Lock()
+	prepare := p.prepare
+	p.mu.Unlock()
+	for _, fn := range prepare {
+		fn(state)
+	}
+}
@@ -1,24 +1,55 @@
 package component
 
 import (
+	"sync"
+
 	"go.kyoto.codes/zen/v3/async"
 	"go.kyoto.codes/zen/v3/errorsx"
 )
 
+// unresolved holds futures, created with Use and not resolved yet,
+// with their contexts (check Defer).
+// Entries keep future closures alive, so closure pointers are not reused.
+var unresolved sync.Map
+
+// deferrable is an unresolved future entry.
+type deferrable struct {
+	ctx    *Context
+	future Future
+}
+
 // Use allows you to use your components in asynchronous way.
 // It's a basic and preferred way to use your components.
+// Future call blocks until the component is resolved.
+// In case of streaming context, `render` function defers
+// not resolved future to the stream instead (check Defer).
 func Use(ctx *Context, component Component) Future {
+	// Create getter, awaiting for the state.
+	var await func() (State, error)
+	getter := Future(func() State {
+		return errorsx.Must(await())
+	})
+	// Register getter as unresolved before starting.
+	key := getter.key()
+	unresolved.Store(key, &deferrable{ctx: ctx, future: getter})
 	// Create state future.
 	ftr := async.New(func() (State, error) {
-		return component(ctx), nil
-	})
-	// Create and return getter.
-	return func() State {
-		// Await for state.
-		state := errorsx.Must(ftr.Await())
-		// Set component name.
-		state.SetName(component.GetName())
+		defer unresolved.Delete(key)
+		// Build state.
+		state := component(ctx)
+		// Set component name, unless it's already set.
+		if state.GetName() == "" {
+			state.SetName(component.GetName())
+		}
+		// Bind state to the context and initialize.
+		Bind(ctx, state)
+		Init(ctx, state)
 		// Return state.
-		return state
-	}
+		return state, nil
+	})
+	await = ftr.Await
+	// Track future within the context.
+	ctx.track(func() { ftr.Await() })
+	// Return getter.
+	return getter
 }
@@ -64,6 +64,16 @@ so you can proceed with our simple starter project.
 
 Feel free to use it as an example for your own setup.
 
+Alternatively, you can use kyoto command to scaffold a new project and components,
+list components with their endpoints and run a development server,
+which rebuilds and restarts the project on changes.
+
+	go install go.kyoto.codes/v3/cmd/kyoto@latest
+	kyoto new <your-new-project> <module>
+	kyoto component Counter -dir <your-new-project>
+	kyoto list <your-new-project>
+	kyoto dev -dir <your-new-project>
+
 # Components
 
 Components is a common approach for modern libraries to manage frontend parts.
@@ -152,6 +162,101 @@ It's easy to do with wrapping component with additional function.
 		}
 	}
 
+Closure wrappers are hiding the component identity,
+so component name can't be resolved properly.
+Preferred way is to use components with typed properties.
+They have an explicit name, default properties,
+and properties can be passed through htmx endpoint calls.
+
+	package main
+
+	type CardProps struct {
+		Title string
+	}
+
+	type CardState struct {
+		component.Universal
+		rendering.Template
+
+		Props CardProps
+	}
+
+	var Card = component.Of("Card", func(ctx *component.Context, props CardProps) component.State {
+		state := &CardState{}
+		state.Props = props
+		return state
+	}).WithDefault(CardProps{Title: "Untitled"})
+
+	func Page(ctx *component.Context) component.State {
+		...
+		state.Card = Card.Use(ctx, CardProps{Title: "Hello"})
+		...
+	}
+
+Component name must not be empty.
+Components, bound to properties, are closures, so they can't be registered with component.Register.
+Register the component with Card.Register(&CardState{}) instead,
+it registers the endpoint by name (check component.Lookup) with optional state prototype.
+
+Register an endpoint with Card.Endpoint() to resolve properties from the request.
+Use `hxprops` function to pass properties with htmx request.
+
+	mux.HandleFunc("/htmx/card", rendering.Handler(Card.Endpoint()))
+
+	<form hx-post="/htmx/card" hx-target="this" hx-swap="outerHTML">
+		{{ hxprops .Props }}
+		...
+	</form>
+
+# Component names
+
+By default, component name is resolved from the component function name.
+It's used as a template name, so it changes when code is refactored
+and may collide across packages.
+To avoid this, register your components with explicit, package-qualified names.
+Registration panics on name collision.
+
+	package cart
+
+	var Badge = component.Register("cart.Badge", func(ctx *component.Context) component.State {
+		...
+	})
+
+Template name is resolved from the registered name.
+
+	{{ define "cart.Badge" }}
+		...
+	{{ end }}
+
+Registered components can be looked up both ways,
+with component.Lookup (by name) and component.NameOf (by component).
+Components are identified by function pointer, so each name needs a distinct function
+(closure instances of the same function literal can't be registered under different names).
+
+# Lifecycle hooks
+
+Component function is not the only place to put your logic.
+State may implement optional lifecycle hooks,
+which are invoked in a documented order:
+Restore (htmx.Post, after the state was restored from the client),
+Init (right after the component function),
+BeforeRender and AfterRender (around the rendering, with handler or `render` function).
+
+	package main
+
+	...
+
+	func (s *PageState) BeforeRender(ctx *component.Context) {
+		// All children are available here
+		for _, item := range s.Items {
+			s.Total += item().(*ItemState).Price
+		}
+		ctx.ResponseWriter.Header().Set("X-Total", fmt.Sprint(s.Total))
+	}
+
+Built-in state implementations are bound to the context they were built within,
+so you can get it with component.ContextOf, having only a state.
+
 # Context
 
 You have an access to the context inside the component.
@@ -244,6 +349,241 @@ Please, don't use this approach heavily now, as it affects rendering performance
 
 	<div>{{ render .Component }}</div>
 
+# Rendering engines
+
+rendering.Template is not the only rendering implementation.
+Any rendering.Renderer implementation can be used with rendering.Handler and `render` function.
+Library provides a few more implementations out of the box:
+rendering.Text (text/template, for non-HTML output like feeds or sitemaps),
+rendering.Func (plain Go function, for programmatic markup)
+and rendering.Templ (adapter for templ-style generated components).
+
+	type ComponentState struct {
+		component.Disposable
+		rendering.Func
+	}
+
+	func Component(ctx *component.Context) component.State {
+		state := &ComponentState{}
+		state.Func.Writer = func(w io.Writer) error {
+			_, err := fmt.Fprint(w, "<div>Hello</div>")
+			return err
+		}
+		return state
+	}
+
+# JSON rendering
+
+The same component state may serve API clients.
+Nest rendering.JSON into the state to opt-in for content negotiation.
+Requests with Accept: application/json (or ?format=json query)
+will get JSON-serialized state (respecting json tags) instead of rendered markup.
+htmx requests (HX-Request header) always get rendered markup.
+Nested component futures are resolved and serialized too.
+Note, it's done with component.Future JSON marshaling, so it applies
+to any JSON encoding of the state (f.e. Universal state marshaling).
+Tag future fields with `json:"-"` to exclude them.
+
+	type ComponentState struct {
+		component.Disposable
+		rendering.Template
+		rendering.JSON // Opt-in for JSON rendering
+
+		Foo string `json:"foo"`
+	}
+
+# Conditional requests
+
+Nest rendering.Conditional into the state to support conditional GET requests.
+With ETag enabled, output is buffered and hashed,
+and client gets 304 Not Modified if it already has the same output.
+If the state knows its version (f.e. record update timestamp), provide it instead,
+so rendering is skipped entirely for matching requests.
+Conditional requests are checked first, then the output is rendered
+with buffering and streaming options of the state (Template.Buffer, Template.Stream, HANDLER_BUFFERED).
+ETag hashing buffers the output itself, so a streamed state must provide Version instead (ETag panics).
+
+	type ComponentState struct {
+		component.Disposable
+		rendering.Template
+		rendering.Conditional
+	}
+
+	func Component(ctx *component.Context) component.State {
+		state := &ComponentState{}
+		state.Conditional.ETag = true
+		state.Conditional.CacheControl = "public, max-age=60"
+		return state
+	}
+
+# Buffered rendering
+
+By default, output is written directly into the response,
+so if rendering fails halfway, client already got 200 status and partial markup.
+Buffered mode commits status, headers and body only after rendering succeeded,
+and falls back to the error page (rendering.HANDLER_ERROR, which logs the error by default) on failure.
+AfterRender hooks are not invoked in this case.
+Enable it per component with Template.Buffer, or globally with rendering.HANDLER_BUFFERED.
+Streaming and buffering are mutually exclusive: streamed components (Template.Stream)
+are not buffered globally, and enabling both per component panics.
+
+Components may set a response status code on the context.
+In buffered mode, it may be set by nested components too.
+
+	func Product(ctx *component.Context) component.State {
+		state := &ProductState{}
+		state.Template.Buffer = true
+		if state.Product == nil {
+			ctx.SetStatus(http.StatusNotFound)
+		}
+		return state
+	}
+
+# Layouts
+
+Instead of defining the full HTML document in each page,
+you can declare a layout chain for your page template.
+Page output is slotted into the section layout, and then into the root layout.
+Layout templates are receiving rendering.Layout as a data.
+
+	func Page(ctx *component.Context) component.State {
+		state := &PageState{}
+		state.Template.Layouts = []string{"SectionLayout", "RootLayout"} // From inner to outer
+		return state
+	}
+
+	{{ define "RootLayout" }}
+	<html>
+		<head>{{ head .State }}</head>
+		<body>{{ .Content }}</body>
+	</html>
+	{{ end }}
+
+htmx partial requests (not boosted ones) are skipping layouts,
+so only the inner content is returned.
+
+# Slots
+
+Container components (cards, modals, etc.) are written once
+and reused with different bodies, passed by the caller.
+Nest rendering.Slots into the container state and use `slot` function in its template.
+
+	type CardState struct {
+		component.Disposable
+		rendering.Template
+		rendering.Slots
+	}
+
+	{{ define "Card" }}
+	<div class="card">
+		<div class="card-header">{{ slot . "header" }}</div>
+		<div class="card-body">{{ slot . "body" }}</div>
+	</div>
+	{{ end }}
+
+Slots can be filled from the parent template with `fill` function,
+using `include` to render own template blocks,
+or from the component code with SetSlot (f.e. from component properties).
+
+	{{ define "CardBody" }}<p>{{ .Text }}</p>{{ end }}
+
+	<div>{{ render .Card (fill "header" "Title" "body" (include "CardBody" .)) }}</div>
+
+Deferred components (streaming) are filled when resolved.
+Lazy components are loaded with a separate request, so fills are ignored for them.
+
+# Streaming
+
+By default, rendering.Handler waits for every component before writing anything,
+so one slow component delays the whole page.
+Streaming mode flushes the page shell immediately,
+renders placeholders for components that are not resolved yet,
+and streams each component markup as it resolves.
+
+	func Page(ctx *component.Context) component.State {
+		state := &PageState{}
+		state.Template.Stream = true // Enable streaming
+		state.Slow = component.Use(ctx, SlowComponent)
+		return state
+	}
+
+Only components rendered with `render` function are streamed,
+so deferred components must implement rendering.
+Calling a future directly (f.e. in component code or hooks) always returns the resolved state.
+
+	<div>{{ render .Slow }}</div>
+
+# Lazy components
+
+Some components are not needed right away (f.e. comments at the bottom of the page).
+Instead of computing them during the page request,
+you can load them lazily with htmx, after the page is loaded.
+Lazy component must be registered with its own handler.
+
+	func Page(ctx *component.Context) component.State {
+		state := &PageState{}
+		state.Comments = component.UseLazy(Comments, component.Lazy{
+			Endpoint: "/htmx/comments",
+			Trigger:  "revealed",                        // Load on scroll ("load" by default)
+			Loading:  template.HTML("<p>Loading...</p>"), // Optional loading markup
+		})
+		return state
+	}
+
+Lazy components are rendered with `render` function.
+
+	<div>{{ render .Comments }}</div>
+
+# Middlewares
+
+Logic like authentication checks, logging or headers setting
+is common for many pages. Instead of duplicating it in each page component,
+you can use handler middlewares. Middleware may short-circuit the request
+by not calling next, or wrap the rendered output by replacing ctx.ResponseWriter.
+
+	package main
+
+	...
+
+	func Auth(ctx *component.Context, next func()) {
+		if ctx.Request.Header.Get("Authorization") == "" {
+			http.Error(ctx.ResponseWriter, "Forbidden", http.StatusForbidden)
+			return
+		}
+		next()
+	}
+
+	func main() {
+		rendering.HANDLER_MIDDLEWARES = append(rendering.HANDLER_MIDDLEWARES, Logging) // Global middleware
+		mux := http.NewServeMux()
+		mux.HandleFunc("/admin", rendering.Handler(Admin, Auth)) // Handler-specific middleware
+		...
+	}
+
+# Page head
+
+Nested components often need to contribute to the page head,
+like title, description, OpenGraph tags or component-specific styles.
+Components can write into the head collector of the context during execution.
+
+	func Product(ctx *component.Context) component.State {
+		...
+		ctx.Head.Title(product.Name)
+		ctx.Head.Meta("description", product.Description)
+		ctx.Head.Property("og:image", product.Image)
+		ctx.Head.Stylesheet("/static/product.css")
+		...
+	}
+
+Use `head` function in your page layout to emit collected and deduplicated elements.
+It waits for all components of the page, so they are able to contribute.
+Streamed pages are the exception: head is flushed with the shell, so `head` doesn't wait,
+and deferred components don't contribute (set their head elements in the page component instead).
+
+	<head>
+		{{ head . }}
+	</head>
+
 # HTMX
 
 HTMX is a frontend library, that allows you to update your page layout dynamically.
@@ -253,6 +593,22 @@ Thanks to the component structure, there is no need to define separate rendering
 # HTMX Setup
 
 Please, check https://htmx.org/docs/#installing for installation instructions.
+As an alternative, htmx package bundles a pinned, compatible htmx version.
+Register the script handler and use `hxscript` function in your layout.
+It emits script tags with pinned subresource integrity (htmx.SCRIPT_INTEGRITY),
+and configuration meta tag if htmx.SCRIPT_CONFIG is provided.
+Distribution files are fetched and verified with "go generate ./htmx".
+Files, which are not bundled, are loaded from the pinned CDN location (htmx.SCRIPT_CDN).
+Extensions (f.e. "sse", "ws") must be pinned with their integrity values before use.
+
+	mux.Handle(htmx.SCRIPT_PREFIX, htmx.ScriptHandler())
+
+	htmx.SCRIPT_INTEGRITY["sse.js"] = "sha384-..."
+
+	<head>
+		{{ hxscript "sse" }}
+	</head>
+
 In addition to this, you must register HTMX handlers for your dynamic components.
 
 	package main
@@ -350,5 +706,266 @@ Let's check how it works on the server side.
 	}
 
 As a result, we have a component with a persistent state between requests.
+
+# Broadcasting
+
+Sometimes one user's action changes data, that other users are looking at right now.
+Package broker allows you to re-render affected components for every connected client.
+Components are subscribed to a topic with Server-Sent Events handler,
+and each published message triggers a component re-render.
+
+	package main
+
+	...
+
+	var b = broker.NewMemory()
+
+	func main() {
+		mux := http.NewServeMux()
+		mux.HandleFunc("/sse/order", broker.Handler(b, func(r *http.Request) string {
+			return "order:" + r.URL.Query().Get("id")
+		}, OrderStatus))
+		...
+	}
+
+	func OrderAction(ctx *component.Context) component.State {
+		...
+		b.Publish("order:42", nil) // Every client, viewing the order, will get updated OrderStatus
+		...
+	}
+
+On the client side, use htmx sse extension. Events are named after the component.
+
+	<div hx-ext="sse" sse-connect="/sse/order?id=42" sse-swap="OrderStatus">
+		{{ render .OrderStatus }}
+	</div>
+
+Memory broker works only within a single process.
+For multiple instances, implement broker.Broker on top of your external broker.
+
+# Static export
+
+Many pages are effectively static.
+Package static renders them at build time through rendering.Handler
+with synthetic requests and writes HTML files (and copied assets) into output directory.
+Dynamic routes are enumerated with Paths function.
+
+	package main
+
+	func main() {
+		err := (&static.Exporter{
+			Output: "dist",
+			Routes: []static.Route{
+				{Path: "/", Component: Index},
+				{Paths: BlogSlugs, Component: BlogPost}, // f.e. "/blog/hello", "/blog/world"
+			},
+			Assets: map[string]fs.FS{"static": os.DirFS("static")},
+		}).Export()
+		...
+	}
+
+# Caching
+
+Expensive components (navigation trees, product grids) don't have to be recomputed on every request.
+Package cache provides a caching layer with TTL and tag-based invalidation,
+keyed by component name and a user-defined key function.
+Memory store is provided out of the box, implement cache.Store for external backends.
+Registered name or function path is used as a cache name by default,
+wrapped components (f.e. component.Guard) and closures require explicit Policy.Name.
+
+Default key is the request URI only, which is the same for every user.
+Requests with credentials (Cookie or Authorization header) are not cached unless Policy.Key is set,
+and explicit key has to include user identity, if the content depends on it.
+
+	var store = cache.NewMemory()
+
+	var policy = cache.Policy{
+		Name: "products.Grid",
+		Key:  func(ctx *component.Context) string { return ctx.Request.URL.Query().Get("category") },
+		TTL:  10 * time.Minute,
+		Tags: func(ctx *component.Context) []string { return []string{"products"} },
+	}
+
+Whole handler responses are cached with a middleware.
+Responses are varied by Accept and HX-Request headers (check cache.PAGE_VARY) and by response Vary header.
+Responses with per-user data (Set-Cookie, private or no-store Cache-Control) are not cached.
+
+	mux.HandleFunc("/products", rendering.Handler(Products, cache.Middleware(store, Products, policy)))
+
+Individual component outputs are cached with cache.Use instead of component.Use.
+On cache hit, component is not executed at all. Render it with `render` function.
+
+	state.Grid = cache.Use(ctx, store, ProductGrid, policy)
+
+Invalidate entries by tags, when underlying data changes.
+
+	store.Invalidate("products")
+
+# Testing
+
+Package kyototest simplifies components testing.
+It runs a component with a fake request, returns resulting state and rendered markup,
+simulates htmx POST requests with marshaled state,
+and provides DOM assertions and golden files comparison.
+
+	func TestComponent(t *testing.T) {
+		result := kyototest.Get(t, Component, "/")
+		result.AssertStatus(200).
+			AssertText("h1.title", "Hello").
+			AssertAttr("form", "hx-post", "/htmx/component").
+			Golden("testdata/component.html") // KYOTOTEST_UPDATE=1 to update
+
+		state := result.State.(*ComponentState)
+		kyototest.Post(t, Component, "/htmx/component", state, url.Values{"foo": {"bar"}}).
+			AssertText(".foo", "bar")
+	}
+
+# Templates checking
+
+Typos in templates (like `{{ .Fooo }}`) are detected only at render time.
+Package check statically checks templates against component state types,
+reporting nonexistent fields and methods, unknown functions and undefined templates.
+State types are registered alongside component names with a state prototype.
+
+	var Badge = component.Register("cart.Badge", BadgeComponent, &BadgeState{})
+
+It's convenient to run the checker as a test in your project.
+Types may be also provided explicitly with State (f.e. for unregistered components).
+
+	func TestTemplates(t *testing.T) {
+		checker := &check.Checker{}
+		checker.State("Page", &PageState{})
+		if err := checker.ParseGlob("*.html"); err != nil {
+			t.Fatal(err)
+		}
+		for _, issue := range checker.Check() {
+			t.Error(issue)
+		}
+	}
+
+kyoto-check command performs the same checks.
+Provide packages with registered components with -pkg flag to check state types,
+otherwise only syntax, unknown functions and undefined templates are checked.
+
+	go run go.kyoto.codes/v3/cmd/kyoto-check -pkg "./components/..." -glob "*.html" -funcs "myfunc"
+
+# Assets
+
+Package assets serves static files (from disk or embedded filesystem)
+with content fingerprinting for cache busting.
+Use `asset` function in your templates to get fingerprinted URL.
+Fingerprinted URLs are served with long-lived immutable cache headers.
+
+	//go:embed static
+	var static embed.FS
+
+	func main() {
+		assets.ASSETS_EMBEDFS = &static // Disk is used by default
+		mux := http.NewServeMux()
+		mux.Handle(assets.ASSETS_PREFIX, assets.Handler())
+		...
+	}
+
+	<link rel="stylesheet" href="{{ asset "app.css" }}"> <!-- /static/app.3f2a9c1d0b.css -->
+
+# Internationalization
+
+Package i18n provides translation catalogs, locale negotiation and `t` template function.
+Messages are loaded from JSON files, named by locale (f.e. locales/de.json).
+Nested objects are flattened into dotted keys, objects with plural categories only
+(zero, one, two, few, many, other) are pluralized messages.
+
+	{
+		"cart": {
+			"title": "Warenkorb von {name}",
+			"items": {"one": "{count} Artikel", "other": "{count} Artikel"}
+		}
+	}
+
+Register the middleware to negotiate the locale (URL prefix, cookie, Accept-Language)
+and store it in the context. Responses are varied by Accept-Language and Cookie headers.
+
+	//go:embed locales
+	var locales embed.FS
+
+	func main() {
+		errorsx.Must(0, i18n.I18N_CATALOG.Load(locales, "locales/*.json"))
+		rendering.HANDLER_MIDDLEWARES = append(rendering.HANDLER_MIDDLEWARES, i18n.Middleware(i18n.I18N_CATALOG))
+		...
+	}
+
+	<h1>{{ t . "cart.title" "name" .User }}</h1>
+	<span>{{ t . "cart.items" "count" .Count }}</span>
+
+Missing translations are falling back to the base language, then to I18N_FALLBACK locale,
+and finally to the key itself.
+
+Formatting functions are using the context locale as well.
+
+	{{ number . .Total 2 }}          <!-- 1,234.50 / 1.234,50 -->
+	{{ currency . .Price "EUR" }}    <!-- €9.99 / 9,99 € -->
+	{{ date . .CreatedAt "long" }}   <!-- March 5, 2024 / 5. März 2024 -->
+	{{ reltime . .UpdatedAt }}       <!-- 5 minutes ago -->
+	{{ bytes . .Size }}              <!-- 1.5 MB -->
+
+Relative time messages can be translated with catalog keys
+"format.reltime.now" and "format.reltime.<unit>.<past|future>" (f.e. "format.reltime.minute.past").
+
+# Guards
+
+Guard wraps a component with access policies, which are checked before the component function
+(including htmx actions, because the component function is executed for them too).
+Policy returns an authenticated principal, exposed as ctx.Principal,
+or an error: *component.Redirect redirects the client (with HX-Redirect header for htmx requests),
+any other error responds with 403 (override component.GUARD_FORBIDDEN to render your own page).
+
+	func Authenticated(ctx *component.Context) (any, error) {
+		user := auth.User(ctx.Request)
+		if user == nil {
+			return nil, &component.Redirect{URL: "/login"}
+		}
+		return user, nil
+	}
+
+	func IsAdmin(ctx *component.Context) (any, error) {
+		user, ok := component.PrincipalOf[*User](ctx)
+		if !ok || !user.Admin {
+			return nil, component.ErrForbidden
+		}
+		return user, nil
+	}
+
+	mux.HandleFunc("/admin", rendering.Handler(component.Guard(AdminPage, Authenticated, IsAdmin)))
+
+# Sessions
+
+Package session provides client sessions with cookie-based (encrypted) and server-side (memory) stores.
+Register the middleware and access the session with session.Of within components.
+Cookies are HttpOnly, Secure (check SESSION_SECURE) and SameSite=Lax by default,
+session lifetime (SESSION_MAX_AGE) is extended on each modification.
+
+	func main() {
+		store := session.NewCookie([]byte(os.Getenv("SESSION_SECRET"))) // or session.NewMemory()
+		rendering.HANDLER_MIDDLEWARES = append(rendering.HANDLER_MIDDLEWARES, session.Middleware(store))
+		...
+	}
+
+	func PLogin(ctx *component.Context) component.State {
+		...
+		s := session.Of(ctx)
+		s.Rotate() // Prevent session fixation
+		s.Set("user", user.ID)
+		s.Set("flash", "Welcome back!")
+		...
+	}
+
+	// Flash message is shown once
+	var flash string
+	session.Of(ctx).Pop("flash", &flash)
+
+Use Destroy to sign out, and Store interface to keep sessions in your database.
+Session cookie is written before the first response write, so modify the session before rendering.
+Later changes (f.e. from streamed components) are saved to server-side stores only,
+cookie store changes made after the output started are lost.
 */
 package kyoto
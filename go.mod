@@ -2,4 +2,7 @@ module go.kyoto.codes/v3
 
 go 1.20
 
-require go.kyoto.codes/zen/v3 v3.0.0
+require (
+	go.kyoto.codes/zen/v3 v3.0.0
+	golang.org/x/net v0.23.0
+)
@@ -17,4 +17,14 @@ var FuncMap = template.FuncMap{
 			`<input type="hidden" name="hx-state" value="%s">`,
 			_state.Marshal(_state)))
 	},
+	// hxprops returns a hidden input with the component properties marshaled as a value.
+	// Use it with typed properties components (component.Props) endpoints.
+	"hxprops": func(props any) template.HTML {
+		return template.HTML(fmt.Sprintf(
+			`<input type="hidden" name="hx-props" value="%s">`,
+			(&component.Universal{}).Marshal(props)))
+	},
+	// hxscript returns bundled htmx script tag (with provided pinned extensions, f.e. "sse"),
+	// pinned to the compatible version and protected with subresource integrity.
+	"hxscript": script,
 }
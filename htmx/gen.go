@@ -0,0 +1,56 @@
+//go:build ignore
+
+// Gen downloads pinned htmx distribution files into dist directory.
+// Every file is verified against htmx.SCRIPT_INTEGRITY before writing,
+// so a compromised or changed upstream file fails the generation.
+package main
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+
+	"go.kyoto.codes/v3/htmx"
+)
+
+func main() {
+	for name, sri := range htmx.SCRIPT_INTEGRITY {
+		if err := fetch(name, sri); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+	}
+}
+
+// fetch downloads and verifies a distribution file.
+func fetch(name, sri string) error {
+	// Resolve location, extensions are located in ext directory
+	url := htmx.SCRIPT_CDN + name
+	if name != "htmx.min.js" {
+		url = htmx.SCRIPT_CDN + "ext/" + name
+	}
+	// Download
+	resp, err := http.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("download %s: %s", url, resp.Status)
+	}
+	content, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	// Verify
+	sum := sha512.Sum384(content)
+	if actual := "sha384-" + base64.StdEncoding.EncodeToString(sum[:]); actual != sri {
+		return fmt.Errorf("verify %s: integrity is %s, pinned %s", url, actual, sri)
+	}
+	// Write
+	return os.WriteFile(filepath.Join("dist", name), content, 0o644)
+}
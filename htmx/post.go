@@ -20,6 +20,9 @@ func Post(ctx *component.Context, state component.State, handler func()) {
 		}
 		// Unmarshal the state from the form
 		state.Unmarshal(state, ctx.Request.FormValue("hx-state"))
+		// Bind the state to the context and notify about restoring
+		component.Bind(ctx, state)
+		component.Restore(ctx, state)
 		// Call the handler
 		handler()
 	}
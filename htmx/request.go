@@ -0,0 +1,19 @@
+package htmx
+
+import "net/http"
+
+// IsRequest checks if the request was made by htmx.
+func IsRequest(r *http.Request) bool {
+	return r.Header.Get("HX-Request") == "true"
+}
+
+// IsBoosted checks if the request was made by htmx boosted element.
+func IsBoosted(r *http.Request) bool {
+	return r.Header.Get("HX-Boosted") == "true"
+}
+
+// IsPartial checks if the request expects a partial content,
+// which means it was made by htmx and it's not a boosted one.
+func IsPartial(r *http.Request) bool {
+	return IsRequest(r) && !IsBoosted(r)
+}
@@ -0,0 +1,137 @@
+package htmx
+
+import (
+	"crypto/sha512"
+	"embed"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// VERSION is a pinned htmx version, compatible with the package helpers.
+const VERSION = "1.9.12"
+
+// Distribution files are downloaded from SCRIPT_CDN
+// and verified against SCRIPT_INTEGRITY.
+//go:generate go run gen.go
+
+//go:embed dist
+var dist embed.FS
+
+// Global script configuration defaults.
+var (
+	SCRIPT_PREFIX                = "/_kyoto/htmx/"                                    // Script handler prefix
+	SCRIPT_CONFIG map[string]any = nil                                                // htmx configuration, emitted as meta tag if provided
+	SCRIPT_CDN                   = "https://unpkg.com/htmx.org@" + VERSION + "/dist/" // Pinned location of files, which are not bundled
+)
+
+// SCRIPT_INTEGRITY holds subresource integrity values of the pinned distribution files.
+// Only pinned files are emitted and served.
+// Bundled files are served only if they match, others are loaded from SCRIPT_CDN
+// and verified by the browser.
+// Pin an extension by adding its value, f.e. "sse.js": "sha384-...".
+var SCRIPT_INTEGRITY = map[string]string{
+	"htmx.min.js": "sha384-ujb1lZYygJmzgSwoxRggbCHcjc0rB2XoQrxeTUQyRjrOnlCoYta87iKBWq3EsdM2",
+}
+
+// cdn returns a pinned CDN location of the distribution file.
+// Extensions are located in ext directory.
+func cdn(name string) string {
+	if name == "htmx.min.js" {
+		return SCRIPT_CDN + name
+	}
+	return SCRIPT_CDN + "ext/" + name
+}
+
+// verified is a cache of bundled files verification results.
+var verified sync.Map
+
+// bundled returns the bundled distribution file,
+// if it matches the pinned integrity value.
+func bundled(name string) ([]byte, bool) {
+	// Read
+	content, err := fs.ReadFile(dist, "dist/"+name)
+	if err != nil {
+		return nil, false
+	}
+	// Lookup cache
+	if ok, cached := verified.Load(name); cached {
+		return content, ok.(bool)
+	}
+	// Verify
+	ok := verify(content, SCRIPT_INTEGRITY[name])
+	verified.Store(name, ok)
+	return content, ok
+}
+
+// verify checks the content against subresource integrity value.
+func verify(content []byte, sri string) bool {
+	sum := sha512.Sum384(content)
+	return sri != "" && sri == "sha384-"+base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// ScriptHandler serves bundled htmx distribution files.
+// Files are served under version path, so they are cached as immutable.
+// Pinned files, which are not bundled (or don't match the pinned integrity),
+// are redirected to SCRIPT_CDN.
+// Register it with SCRIPT_PREFIX.
+//
+//	mux.Handle(htmx.SCRIPT_PREFIX, htmx.ScriptHandler())
+func ScriptHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// Resolve file name
+		name := strings.TrimPrefix(r.URL.Path, SCRIPT_PREFIX+VERSION+"/")
+		if name == r.URL.Path {
+			http.NotFound(w, r)
+			return
+		}
+		if _, ok := SCRIPT_INTEGRITY[name]; !ok {
+			http.NotFound(w, r)
+			return
+		}
+		// Read, falling back to CDN
+		content, ok := bundled(name)
+		if !ok {
+			http.Redirect(w, r, cdn(name), http.StatusFound)
+			return
+		}
+		// Serve
+		w.Header().Set("Content-Type", "text/javascript; charset=utf-8")
+		w.Header().Set("Cache-Control", "public, max-age=31536000, immutable")
+		w.Write(content)
+	})
+}
+
+// script returns htmx (and extensions) script tags
+// with subresource integrity, and optional configuration meta tag.
+// Panics on files, which are not pinned.
+func script(extensions ...string) template.HTML {
+	tags := []string{}
+	// Configuration
+	if len(SCRIPT_CONFIG) > 0 {
+		config, err := json.Marshal(SCRIPT_CONFIG)
+		if err != nil {
+			panic(err)
+		}
+		tags = append(tags, fmt.Sprintf(`<meta name="htmx-config" content="%s">`, template.HTMLEscapeString(string(config))))
+	}
+	// Scripts
+	for _, name := range append([]string{"htmx.min"}, extensions...) {
+		name += ".js"
+		sri, ok := SCRIPT_INTEGRITY[name]
+		if !ok {
+			panic(fmt.Sprintf("htmx distribution file %s is not pinned (check SCRIPT_INTEGRITY)", name))
+		}
+		src := SCRIPT_PREFIX + VERSION + "/" + name
+		if _, ok := bundled(name); !ok {
+			src = cdn(name)
+		}
+		tags = append(tags, fmt.Sprintf(`<script src="%s" integrity="%s" crossorigin="anonymous"></script>`, src, sri))
+	}
+	return template.HTML(strings.Join(tags, "\n"))
+}
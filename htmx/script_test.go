@@ -0,0 +1,80 @@
+package htmx
+
+import (
+	"crypto/sha512"
+	"encoding/base64"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestScript(t *testing.T) {
+	html := string(script())
+	want := `integrity="` + SCRIPT_INTEGRITY["htmx.min.js"] + `" crossorigin="anonymous"`
+	if !strings.Contains(html, want) {
+		t.Errorf("pinned integrity is missing in %s", html)
+	}
+	// Bundled file is referenced locally
+	if _, ok := bundled("htmx.min.js"); ok && !strings.Contains(html, `src="`+SCRIPT_PREFIX+VERSION+`/htmx.min.js"`) {
+		t.Errorf("bundled file is not referenced in %s", html)
+	}
+	// Not pinned files are rejected
+	defer func() {
+		if recover() == nil {
+			t.Error("not pinned extension doesn't panic")
+		}
+	}()
+	script("unknown")
+}
+
+func TestScriptHandler(t *testing.T) {
+	// Not pinned files
+	for _, path := range []string{
+		SCRIPT_PREFIX + VERSION + "/README.md",
+		SCRIPT_PREFIX + VERSION + "/ext/sse.js",
+		SCRIPT_PREFIX + "0.0.0/htmx.min.js",
+	} {
+		rec := httptest.NewRecorder()
+		ScriptHandler().ServeHTTP(rec, httptest.NewRequest("GET", path, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("%s: status %d, expected 404", path, rec.Code)
+		}
+	}
+	// Pinned files
+	for name, sri := range SCRIPT_INTEGRITY {
+		if _, err := fs.Stat(dist, "dist/"+name); err != nil {
+			t.Skipf("%s is not bundled, run go generate ./htmx", name)
+		}
+		rec := httptest.NewRecorder()
+		ScriptHandler().ServeHTTP(rec, httptest.NewRequest("GET", SCRIPT_PREFIX+VERSION+"/"+name, nil))
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: status %d, expected 200", name, rec.Code)
+		}
+		sum := sha512.Sum384(rec.Body.Bytes())
+		if actual := "sha384-" + base64.StdEncoding.EncodeToString(sum[:]); actual != sri {
+			t.Errorf("%s: served integrity %s, pinned %s", name, actual, sri)
+		}
+	}
+}
+
+func TestVerify(t *testing.T) {
+	content := []byte("htmx")
+	sum := sha512.Sum384(content)
+	sri := "sha384-" + base64.StdEncoding.EncodeToString(sum[:])
+	tests := []struct {
+		content []byte
+		sri     string
+		want    bool
+	}{
+		{content, sri, true},
+		{[]byte("tampered"), sri, false},
+		{content, "", false},
+	}
+	for _, tt := range tests {
+		if got := verify(tt.content, tt.sri); got != tt.want {
+			t.Errorf("verify(%q, %q) = %v", tt.content, tt.sri, got)
+		}
+	}
+}
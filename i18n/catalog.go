@@ -0,0 +1,214 @@
+package i18n
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// Global i18n configuration defaults.
+var (
+	I18N_FALLBACK = "en"     // Fallback locale
+	I18N_COOKIE   = "locale" // Locale cookie name
+	I18N_CATALOG  = NewCatalog()
+)
+
+// pluralCategories is a set of CLDR plural categories.
+var pluralCategories = map[string]bool{
+	"zero": true, "one": true, "two": true, "few": true, "many": true, "other": true,
+}
+
+// Message is a translation message.
+// Simple messages have "other" form only,
+// pluralized messages have forms by plural category (zero, one, two, few, many, other).
+type Message map[string]string
+
+// Catalog holds translation messages by locale.
+// It's safe for concurrent use.
+type Catalog struct {
+	mu       sync.RWMutex
+	messages map[string]map[string]Message
+}
+
+// NewCatalog initializes an empty catalog.
+func NewCatalog() *Catalog {
+	return &Catalog{
+		messages: map[string]map[string]Message{},
+	}
+}
+
+// Add adds a message to the catalog.
+func (c *Catalog) Add(locale, key string, message Message) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	locale = normalize(locale)
+	if c.messages == nil {
+		c.messages = map[string]map[string]Message{}
+	}
+	if c.messages[locale] == nil {
+		c.messages[locale] = map[string]Message{}
+	}
+	c.messages[locale][key] = message
+}
+
+// Load loads message files, matching the glob pattern, from the filesystem.
+// Each file is a JSON object, named after the locale (f.e. "locales/en.json").
+// Nested objects are flattened into dot-separated keys,
+// objects with plural categories keys only are pluralized messages.
+//
+//	{
+//		"title": "Hello, {name}!",
+//		"cart": {
+//			"items": {"one": "{count} item", "other": "{count} items"}
+//		}
+//	}
+func (c *Catalog) Load(fsys fs.FS, pattern string) error {
+	// Resolve files
+	files, err := fs.Glob(fsys, pattern)
+	if err != nil {
+		return err
+	}
+	// Load each file
+	for _, file := range files {
+		// Read and decode
+		content, err := fs.ReadFile(fsys, file)
+		if err != nil {
+			return err
+		}
+		tree := map[string]any{}
+		if err := json.Unmarshal(content, &tree); err != nil {
+			return fmt.Errorf("decode %s: %w", file, err)
+		}
+		// Flatten into catalog
+		locale := strings.TrimSuffix(path.Base(file), path.Ext(file))
+		if err := c.flatten(locale, "", tree); err != nil {
+			return fmt.Errorf("load %s: %w", file, err)
+		}
+	}
+	return nil
+}
+
+// flatten adds nested messages tree into the catalog.
+func (c *Catalog) flatten(locale, prefix string, tree map[string]any) error {
+	for key, value := range tree {
+		// Build key
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+		// Add message
+		switch value := value.(type) {
+		case string:
+			c.Add(locale, key, Message{"other": value})
+		case map[string]any:
+			// Plural message
+			if plural(value) {
+				message := Message{}
+				for category, form := range value {
+					message[category] = form.(string)
+				}
+				c.Add(locale, key, message)
+				continue
+			}
+			// Nested messages
+			if err := c.flatten(locale, key, value); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("unexpected value of %s", key)
+		}
+	}
+	return nil
+}
+
+// plural checks if the object is a plural message.
+func plural(value map[string]any) bool {
+	for category, form := range value {
+		if _, ok := form.(string); !ok || !pluralCategories[category] {
+			return false
+		}
+	}
+	return len(value) > 0
+}
+
+// Locales returns a sorted list of catalog locales.
+func (c *Catalog) Locales() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	locales := make([]string, 0, len(c.messages))
+	for locale := range c.messages {
+		locales = append(locales, locale)
+	}
+	sort.Strings(locales)
+	return locales
+}
+
+// lookup finds a message, falling back from regional to base language
+// (f.e. "de-AT" to "de") and to the fallback locale.
+func (c *Catalog) lookup(locale, key string) (Message, string, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	for _, candidate := range []string{normalize(locale), base(locale), normalize(I18N_FALLBACK)} {
+		if message, ok := c.messages[candidate][key]; ok {
+			return message, candidate, true
+		}
+	}
+	return nil, "", false
+}
+
+// Translate returns a translated message.
+// Arguments are name/value pairs for interpolation into {name} placeholders.
+// "count" argument selects the plural form.
+// Returns the key itself if the message is not found.
+func (c *Catalog) Translate(locale, key string, args ...any) string {
+	// Lookup
+	message, found, ok := c.lookup(locale, key)
+	if !ok {
+		return key
+	}
+	// Format
+	return message.format(found, args...)
+}
+
+// format selects the message form for the locale and interpolates arguments.
+func (m Message) format(locale string, args ...any) string {
+	// Collect arguments
+	params := map[string]string{}
+	var count any
+	for i := 0; i+1 < len(args); i += 2 {
+		name := fmt.Sprint(args[i])
+		params[name] = fmt.Sprint(args[i+1])
+		if name == "count" {
+			count = args[i+1]
+		}
+	}
+	// Select form
+	form := m["other"]
+	if count != nil {
+		if f, ok := m[Category(locale, count)]; ok {
+			form = f
+		}
+		if f, ok := m["zero"]; ok && fmt.Sprint(count) == "0" {
+			form = f
+		}
+	}
+	// Interpolate
+	for name, value := range params {
+		form = strings.ReplaceAll(form, "{"+name+"}", value)
+	}
+	return form
+}
+
+// normalize converts locale into canonical lower-case form with dash separator.
+func normalize(locale string) string {
+	return strings.ToLower(strings.ReplaceAll(strings.TrimSpace(locale), "_", "-"))
+}
+
+// base returns the base language of the locale (f.e. "de" for "de-AT").
+func base(locale string) string {
+	language, _, _ := strings.Cut(normalize(locale), "-")
+	return language
+}
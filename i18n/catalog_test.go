@@ -0,0 +1,90 @@
+package i18n
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func testCatalog(t *testing.T) *Catalog {
+	t.Helper()
+	catalog := NewCatalog()
+	err := catalog.Load(fstest.MapFS{
+		"locales/en.json": {Data: []byte(`{
+			"title": "Hello, {name}!",
+			"only": "English only",
+			"cart": {
+				"items": {"zero": "No items", "one": "{count} item", "other": "{count} items"},
+				"nested": {"deep": "Deep"}
+			}
+		}`)},
+		"locales/de.json": {Data: []byte(`{
+			"title": "Hallo, {name}!",
+			"cart": {"items": {"one": "{count} Artikel", "other": "{count} Artikel"}}
+		}`)},
+		"locales/de-AT.json": {Data: []byte(`{"title": "Servus, {name}!"}`)},
+		"locales/ru.json":    {Data: []byte(`{"cart": {"items": {"one": "{count} товар", "few": "{count} товара", "many": "{count} товаров"}}}`)},
+	}, "locales/*.json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return catalog
+}
+
+func TestCatalogLoad(t *testing.T) {
+	catalog := testCatalog(t)
+	if locales := catalog.Locales(); len(locales) != 4 || locales[0] != "de" || locales[1] != "de-at" {
+		t.Errorf("Locales() = %v", locales)
+	}
+	// Flattened keys and plural messages
+	if message, _, ok := catalog.lookup("en", "cart.nested.deep"); !ok || message["other"] != "Deep" {
+		t.Errorf("nested message is %v, %v", message, ok)
+	}
+	if message, _, ok := catalog.lookup("en", "cart.items"); !ok || len(message) != 3 {
+		t.Errorf("plural message is %v, %v", message, ok)
+	}
+	// Invalid values
+	err := NewCatalog().Load(fstest.MapFS{"en.json": {Data: []byte(`{"count": 1}`)}}, "*.json")
+	if err == nil {
+		t.Error("invalid value is loaded")
+	}
+	err = NewCatalog().Load(fstest.MapFS{"en.json": {Data: []byte(`{"title": `)}}, "*.json")
+	if err == nil {
+		t.Error("invalid file is loaded")
+	}
+}
+
+func TestTranslate(t *testing.T) {
+	defer func(fallback string) { I18N_FALLBACK = fallback }(I18N_FALLBACK)
+	I18N_FALLBACK = "en"
+	catalog := testCatalog(t)
+	tests := []struct {
+		locale string
+		key    string
+		args   []any
+		want   string
+	}{
+		{"en", "title", []any{"name", "Ann"}, "Hello, Ann!"},
+		{"de", "title", []any{"name", "Ann"}, "Hallo, Ann!"},
+		{"de-AT", "title", []any{"name", "Ann"}, "Servus, Ann!"},
+		{"de_at", "title", []any{"name", "Ann"}, "Servus, Ann!"},
+		{"de-CH", "title", []any{"name", "Ann"}, "Hallo, Ann!"},
+		{"de-AT", "cart.items", []any{"count", 2}, "2 Artikel"},
+		{"fr", "title", []any{"name", "Ann"}, "Hello, Ann!"},
+		{"de", "only", nil, "English only"},
+		{"de", "missing", nil, "missing"},
+		{"en", "cart", nil, "cart"},
+		{"en", "cart.items", []any{"count", 0}, "No items"},
+		{"en", "cart.items", []any{"count", 1}, "1 item"},
+		{"en", "cart.items", []any{"count", 5}, "5 items"},
+		{"en", "cart.items", nil, "{count} items"},
+		{"ru", "cart.items", []any{"count", 21}, "21 товар"},
+		{"ru", "cart.items", []any{"count", 3}, "3 товара"},
+		{"ru", "cart.items", []any{"count", 11}, "11 товаров"},
+		{"en", "title", []any{"name"}, "Hello, {name}!"},
+	}
+	for _, tt := range tests {
+		if got := catalog.Translate(tt.locale, tt.key, tt.args...); got != tt.want {
+			t.Errorf("Translate(%q, %q, %v) = %q, want %q", tt.locale, tt.key, tt.args, got, tt.want)
+		}
+	}
+}
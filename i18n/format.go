@@ -0,0 +1,326 @@
+package i18n
+
+import (
+	"fmt"
+	"html/template"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/zen/v3/logic"
+)
+
+// FormatFuncMap holds locale-aware formatting template functions.
+// Each function reads the locale from the state context (falls back to I18N_FALLBACK).
+var FormatFuncMap = template.FuncMap{
+	// number formats a number with locale separators.
+	// Optional argument is a number of decimals.
+	//
+	//	{{ number . .Total 2 }} <!-- 1,234.50 / 1.234,50 -->
+	"number": func(state component.State, value any, decimals ...int) string {
+		return FormatNumber(localeOf(component.ContextOf(state)), value, decimals...)
+	},
+	// currency formats an amount with currency symbol by ISO 4217 code.
+	//
+	//	{{ currency . .Price "EUR" }} <!-- €9.99 / 9,99 € -->
+	"currency": func(state component.State, amount any, code string) string {
+		return FormatCurrency(localeOf(component.ContextOf(state)), amount, code)
+	},
+	// date formats a time with style ("short", "long", "time") or Go layout.
+	// Default style is "short".
+	//
+	//	{{ date . .CreatedAt "long" }} <!-- January 2, 2006 / 2. Januar 2006 -->
+	"date": func(state component.State, t time.Time, style ...string) string {
+		return FormatDate(localeOf(component.ContextOf(state)), t, logic.Or(append(style, "short")...))
+	},
+	// reltime formats a time relative to the current time.
+	//
+	//	{{ reltime . .UpdatedAt }} <!-- 5 minutes ago / in 2 days -->
+	"reltime": func(state component.State, t time.Time) string {
+		return FormatRelative(localeOf(component.ContextOf(state)), t, time.Now())
+	},
+	// bytes formats a byte size with decimal units.
+	//
+	//	{{ bytes . .Size }} <!-- 1.5 MB -->
+	"bytes": func(state component.State, size any) string {
+		return FormatBytes(localeOf(component.ContextOf(state)), size)
+	},
+}
+
+// convention holds locale formatting conventions.
+type convention struct {
+	Decimal        string     // Decimal separator
+	Group          string     // Digits group separator
+	CurrencySuffix bool       // Currency symbol placement
+	Short          string     // Short date layout
+	Long           string     // Long date layout with {day}, {month}, {year} placeholders
+	Time           string     // Time layout
+	Months         [12]string // Month names, used in long date
+}
+
+var (
+	monthsEN  = [12]string{"January", "February", "March", "April", "May", "June", "July", "August", "September", "October", "November", "December"}
+	monthsDE  = [12]string{"Januar", "Februar", "März", "April", "Mai", "Juni", "Juli", "August", "September", "Oktober", "November", "Dezember"}
+	monthsFR  = [12]string{"janvier", "février", "mars", "avril", "mai", "juin", "juillet", "août", "septembre", "octobre", "novembre", "décembre"}
+	monthsES  = [12]string{"enero", "febrero", "marzo", "abril", "mayo", "junio", "julio", "agosto", "septiembre", "octubre", "noviembre", "diciembre"}
+	monthsIT  = [12]string{"gennaio", "febbraio", "marzo", "aprile", "maggio", "giugno", "luglio", "agosto", "settembre", "ottobre", "novembre", "dicembre"}
+	monthsPT  = [12]string{"janeiro", "fevereiro", "março", "abril", "maio", "junho", "julho", "agosto", "setembro", "outubro", "novembro", "dezembro"}
+	monthsNL  = [12]string{"januari", "februari", "maart", "april", "mei", "juni", "juli", "augustus", "september", "oktober", "november", "december"}
+	monthsPL  = [12]string{"stycznia", "lutego", "marca", "kwietnia", "maja", "czerwca", "lipca", "sierpnia", "września", "października", "listopada", "grudnia"}
+	monthsRU  = [12]string{"января", "февраля", "марта", "апреля", "мая", "июня", "июля", "августа", "сентября", "октября", "ноября", "декабря"}
+	monthsUK  = [12]string{"січня", "лютого", "березня", "квітня", "травня", "червня", "липня", "серпня", "вересня", "жовтня", "листопада", "грудня"}
+	monthsCJK = [12]string{"1", "2", "3", "4", "5", "6", "7", "8", "9", "10", "11", "12"}
+)
+
+// conventions holds formatting conventions by locale or base language.
+// Unknown locales are using "en" conventions.
+var conventions = map[string]convention{
+	"en":    {".", ",", false, "01/02/2006", "{month} {day}, {year}", "3:04 PM", monthsEN},
+	"en-gb": {".", ",", false, "02/01/2006", "{day} {month} {year}", "15:04", monthsEN},
+	"de":    {",", ".", true, "02.01.2006", "{day}. {month} {year}", "15:04", monthsDE},
+	"de-ch": {".", "’", false, "02.01.2006", "{day}. {month} {year}", "15:04", monthsDE},
+	"fr":    {",", " ", true, "02/01/2006", "{day} {month} {year}", "15:04", monthsFR},
+	"es":    {",", ".", true, "02/01/2006", "{day} de {month} de {year}", "15:04", monthsES},
+	"it":    {",", ".", true, "02/01/2006", "{day} {month} {year}", "15:04", monthsIT},
+	"pt":    {",", ".", true, "02/01/2006", "{day} de {month} de {year}", "15:04", monthsPT},
+	"nl":    {",", ".", false, "02-01-2006", "{day} {month} {year}", "15:04", monthsNL},
+	"pl":    {",", " ", true, "02.01.2006", "{day} {month} {year}", "15:04", monthsPL},
+	"ru":    {",", " ", true, "02.01.2006", "{day} {month} {year} г.", "15:04", monthsRU},
+	"uk":    {",", " ", true, "02.01.2006", "{day} {month} {year} р.", "15:04", monthsUK},
+	"ja":    {".", ",", false, "2006/01/02", "{year}年{month}月{day}日", "15:04", monthsCJK},
+	"zh":    {".", ",", false, "2006/01/02", "{year}年{month}月{day}日", "15:04", monthsCJK},
+	"ko":    {".", ",", false, "2006. 01. 02.", "{year}년 {month}월 {day}일", "15:04", monthsCJK},
+}
+
+// currencies holds currency symbols by ISO 4217 code.
+var currencies = map[string]string{
+	"USD": "$", "EUR": "€", "GBP": "£", "JPY": "¥", "CNY": "¥", "KRW": "₩",
+	"INR": "₹", "UAH": "₴", "RUB": "₽", "PLN": "zł", "TRY": "₺", "BRL": "R$",
+}
+
+// currencyDecimals holds currencies without minor units.
+// Other currencies are using 2 decimals.
+var currencyDecimals = map[string]int{
+	"JPY": 0, "KRW": 0, "VND": 0, "CLP": 0, "ISK": 0,
+}
+
+// conventionOf resolves formatting conventions of the locale,
+// falling back from regional to base language and to "en".
+func conventionOf(locale string) convention {
+	for _, candidate := range []string{normalize(locale), base(locale), base(I18N_FALLBACK)} {
+		if c, ok := conventions[candidate]; ok {
+			return c
+		}
+	}
+	return conventions["en"]
+}
+
+// FormatNumber formats a number with locale separators.
+// Optional argument is a number of decimals,
+// integers are formatted without decimals by default
+// and floats with the minimal needed number of decimals.
+// Negative number of decimals is ignored.
+func FormatNumber(locale string, value any, decimals ...int) string {
+	// Resolve value and precision
+	f, ok := float(value)
+	if !ok {
+		return fmt.Sprint(value)
+	}
+	precision := -1
+	if _, isint := integer(value); isint {
+		precision = 0
+	}
+	if len(decimals) > 0 && decimals[0] >= 0 {
+		precision = decimals[0]
+	}
+	// Format, keeping large integers exact
+	c := conventionOf(locale)
+	formatted := strconv.FormatFloat(math.Abs(f), 'f', precision, 64)
+	if digits, ok := digits(value); ok && precision >= 0 {
+		formatted = digits
+		if precision > 0 {
+			formatted += "." + strings.Repeat("0", precision)
+		}
+	}
+	whole, fraction, _ := strings.Cut(formatted, ".")
+	// Group digits
+	grouped := strings.Builder{}
+	for i, digit := range whole {
+		if i > 0 && (len(whole)-i)%3 == 0 {
+			grouped.WriteString(c.Group)
+		}
+		grouped.WriteRune(digit)
+	}
+	// Compose
+	result := grouped.String()
+	if fraction != "" {
+		result += c.Decimal + fraction
+	}
+	if f < 0 && strings.Trim(formatted, "0.") != "" {
+		result = "-" + result
+	}
+	return result
+}
+
+// FormatCurrency formats an amount with currency symbol by ISO 4217 code.
+// Unknown currencies are using code instead of symbol.
+func FormatCurrency(locale string, amount any, code string) string {
+	// Resolve symbol and decimals
+	code = strings.ToUpper(code)
+	symbol, ok := currencies[code]
+	if !ok {
+		symbol = code
+	}
+	decimals, ok := currencyDecimals[code]
+	if !ok {
+		decimals = 2
+	}
+	// Format
+	number := FormatNumber(locale, amount, decimals)
+	if conventionOf(locale).CurrencySuffix {
+		return number + " " + symbol
+	}
+	if negative := strings.HasPrefix(number, "-"); negative {
+		return "-" + symbol + number[1:]
+	}
+	return symbol + number
+}
+
+// FormatDate formats a time with locale conventions.
+// Style is one of "short", "long", "time" or Go time layout.
+func FormatDate(locale string, t time.Time, style string) string {
+	c := conventionOf(locale)
+	switch style {
+	case "short":
+		return t.Format(c.Short)
+	case "long":
+		return strings.NewReplacer(
+			"{day}", strconv.Itoa(t.Day()),
+			"{month}", c.Months[t.Month()-1],
+			"{year}", strconv.Itoa(t.Year()),
+		).Replace(c.Long)
+	case "time":
+		return t.Format(c.Time)
+	default:
+		return t.Format(style)
+	}
+}
+
+// relativeUnits holds relative time units, from largest to smallest.
+var relativeUnits = []struct {
+	Name     string
+	Duration time.Duration
+}{
+	{"year", 365 * 24 * time.Hour},
+	{"month", 30 * 24 * time.Hour},
+	{"day", 24 * time.Hour},
+	{"hour", time.Hour},
+	{"minute", time.Minute},
+	{"second", time.Second},
+}
+
+// relativeMessages holds default (English) relative time messages.
+// Catalog messages with the same keys are taking precedence.
+var relativeMessages = map[string]Message{
+	"format.reltime.now": {"other": "now"},
+}
+
+func init() {
+	for _, unit := range relativeUnits {
+		relativeMessages["format.reltime."+unit.Name+".past"] = Message{"one": "{count} " + unit.Name + " ago", "other": "{count} " + unit.Name + "s ago"}
+		relativeMessages["format.reltime."+unit.Name+".future"] = Message{"one": "in {count} " + unit.Name, "other": "in {count} " + unit.Name + "s"}
+	}
+}
+
+// FormatRelative formats a time relative to now (f.e. "5 minutes ago", "in 2 days").
+// Messages are taken from the global catalog (I18N_CATALOG) by keys
+// "format.reltime.now" and "format.reltime.<unit>.<past|future>",
+// where unit is one of year, month, day, hour, minute, second.
+// English messages are used if not found.
+func FormatRelative(locale string, t, now time.Time) string {
+	// Resolve direction
+	diff := t.Sub(now)
+	direction := "future"
+	if diff < 0 {
+		diff, direction = -diff, "past"
+	}
+	// Resolve unit and key
+	key, count := "format.reltime.now", int64(0)
+	for _, unit := range relativeUnits {
+		if diff >= unit.Duration {
+			key, count = "format.reltime."+unit.Name+"."+direction, int64(diff/unit.Duration)
+			break
+		}
+	}
+	// Format
+	if message, found, ok := I18N_CATALOG.lookup(locale, key); ok {
+		return message.format(found, "count", count)
+	}
+	return relativeMessages[key].format("en", "count", count)
+}
+
+// FormatBytes formats a byte size with decimal (SI) units (f.e. "1.5 MB").
+func FormatBytes(locale string, size any) string {
+	// Resolve size
+	f, ok := float(size)
+	if !ok {
+		return fmt.Sprint(size)
+	}
+	// Scale, taking rounding into account (999 950 B is 1 MB, not 1,000 kB).
+	// Bytes are formatted without decimals, other units with one decimal.
+	units := []string{"B", "kB", "MB", "GB", "TB", "PB"}
+	unit, scale := 0, 1.0
+	for math.Abs(math.Round(f*scale)/scale) >= 1000 && unit < len(units)-1 {
+		f /= 1000
+		unit, scale = unit+1, 10
+	}
+	// Format
+	if unit == 0 {
+		return FormatNumber(locale, math.Round(f), 0) + " " + units[unit]
+	}
+	number := FormatNumber(locale, math.Round(f*scale)/scale, 1)
+	number = strings.TrimSuffix(number, conventionOf(locale).Decimal+"0")
+	return number + " " + units[unit]
+}
+
+// float converts numeric value into float64.
+func float(value any) (float64, bool) {
+	switch value := value.(type) {
+	case float64:
+		return value, true
+	case float32:
+		return float64(value), true
+	case uint:
+		return float64(value), true
+	case uint64:
+		return float64(value), true
+	default:
+		if n, ok := integer(value); ok {
+			return float64(n), true
+		}
+		f, err := strconv.ParseFloat(fmt.Sprint(value), 64)
+		return f, err == nil
+	}
+}
+
+// digits returns exact absolute digits of integer values,
+// which are losing precision in float64.
+func digits(value any) (string, bool) {
+	switch value := value.(type) {
+	case int:
+		return digits(int64(value))
+	case int64:
+		if value < 0 {
+			return strconv.FormatUint(uint64(-(value+1))+1, 10), true
+		}
+		return strconv.FormatUint(uint64(value), 10), true
+	case uint:
+		return strconv.FormatUint(uint64(value), 10), true
+	case uint64:
+		return strconv.FormatUint(value, 10), true
+	default:
+		return "", false
+	}
+}
@@ -0,0 +1,176 @@
+package i18n
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFormatNumber(t *testing.T) {
+	tests := []struct {
+		locale   string
+		value    any
+		decimals []int
+		want     string
+	}{
+		// Conventions
+		{"en", 1234567, nil, "1,234,567"},
+		{"en", 1234.5, []int{2}, "1,234.50"},
+		{"en-US", 1234.5, nil, "1,234.5"},
+		{"de", 1234.5, []int{2}, "1.234,50"},
+		{"de-CH", 1234.5, []int{2}, "1’234.50"},
+		{"fr", 1234567.25, nil, "1\u202f234\u202f567,25"},
+		{"ru", 1234.5, nil, "1\u00a0234,5"},
+		{"pl", 1234, nil, "1\u00a0234"},
+		{"ja", 1234.5, nil, "1,234.5"},
+		{"xx", 1234.5, nil, "1,234.5"},
+		// Values
+		{"en", 0, nil, "0"},
+		{"en", 999, nil, "999"},
+		{"en", 1000, nil, "1,000"},
+		{"en", -1234, nil, "-1,234"},
+		{"en", int8(-12), nil, "-12"},
+		{"en", float32(1.5), nil, "1.5"},
+		{"en", 2.0, nil, "2"},
+		{"en", "1234", nil, "1,234"},
+		{"en", "x", nil, "x"},
+		// Precision
+		{"en", 1234, []int{2}, "1,234.00"},
+		{"en", 1.005, []int{0}, "1"},
+		{"en", 1.5, []int{-1}, "1.5"},
+		{"en", 1234, []int{-2}, "1,234"},
+		// Negative zero
+		{"en", math.Copysign(0, -1), nil, "0"},
+		{"en", -0.001, []int{2}, "0.00"},
+		{"en", -0.4, []int{0}, "0"},
+		// Large integers
+		{"en", uint64(math.MaxUint64), nil, "18,446,744,073,709,551,615"},
+		{"en", int64(math.MaxInt64), nil, "9,223,372,036,854,775,807"},
+		{"en", int64(math.MinInt64), nil, "-9,223,372,036,854,775,808"},
+		{"de", uint64(math.MaxUint64), []int{1}, "18.446.744.073.709.551.615,0"},
+	}
+	for _, tt := range tests {
+		if got := FormatNumber(tt.locale, tt.value, tt.decimals...); got != tt.want {
+			t.Errorf("FormatNumber(%q, %#v, %v) = %q, want %q", tt.locale, tt.value, tt.decimals, got, tt.want)
+		}
+	}
+}
+
+func TestFormatCurrency(t *testing.T) {
+	tests := []struct {
+		locale string
+		amount any
+		code   string
+		want   string
+	}{
+		{"en", 9.99, "USD", "$9.99"},
+		{"en", -9.99, "usd", "-$9.99"},
+		{"en", 1234, "EUR", "€1,234.00"},
+		{"en", 1234.5, "JPY", "¥1,234"},
+		{"en", 10, "CHF", "CHF10.00"},
+		{"en", -0.001, "USD", "$0.00"},
+		{"de", 9.99, "EUR", "9,99\u00a0€"},
+		{"de", -1234.5, "EUR", "-1.234,50\u00a0€"},
+		{"fr", 1234.5, "EUR", "1\u202f234,50\u00a0€"},
+		{"uk", 100, "UAH", "100,00\u00a0₴"},
+		{"ja", 1500, "JPY", "¥1,500"},
+	}
+	for _, tt := range tests {
+		if got := FormatCurrency(tt.locale, tt.amount, tt.code); got != tt.want {
+			t.Errorf("FormatCurrency(%q, %#v, %q) = %q, want %q", tt.locale, tt.amount, tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestFormatBytes(t *testing.T) {
+	tests := []struct {
+		locale string
+		size   any
+		want   string
+	}{
+		{"en", 0, "0 B"},
+		{"en", 999, "999 B"},
+		{"en", 999.6, "1 kB"},
+		{"en", 1000, "1 kB"},
+		{"en", 1500, "1.5 kB"},
+		{"en", 999949, "999.9 kB"},
+		{"en", 999950, "1 MB"},
+		{"en", 1500000, "1.5 MB"},
+		{"en", -1500, "-1.5 kB"},
+		{"en", uint64(math.MaxUint64), "18,446.7 PB"},
+		{"en", "x", "x"},
+		{"de", 1500, "1,5 kB"},
+		{"de", 2000000, "2 MB"},
+		{"fr", 1250000000, "1,3 GB"},
+	}
+	for _, tt := range tests {
+		if got := FormatBytes(tt.locale, tt.size); got != tt.want {
+			t.Errorf("FormatBytes(%q, %#v) = %q, want %q", tt.locale, tt.size, got, tt.want)
+		}
+	}
+}
+
+func TestFormatDate(t *testing.T) {
+	date := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		locale string
+		style  string
+		want   string
+	}{
+		{"en", "short", "01/02/2006"},
+		{"en", "long", "January 2, 2006"},
+		{"en", "time", "3:04 PM"},
+		{"en-GB", "short", "02/01/2006"},
+		{"en-GB", "long", "2 January 2006"},
+		{"de", "short", "02.01.2006"},
+		{"de", "long", "2. Januar 2006"},
+		{"de", "time", "15:04"},
+		{"fr", "long", "2 janvier 2006"},
+		{"es", "long", "2 de enero de 2006"},
+		{"ru", "long", "2 января 2006 г."},
+		{"ja", "short", "2006/01/02"},
+		{"ja", "long", "2006年1月2日"},
+		{"xx", "short", "01/02/2006"},
+		{"en", "2006-01-02", "2006-01-02"},
+	}
+	for _, tt := range tests {
+		if got := FormatDate(tt.locale, date, tt.style); got != tt.want {
+			t.Errorf("FormatDate(%q, %q) = %q, want %q", tt.locale, tt.style, got, tt.want)
+		}
+	}
+}
+
+func TestFormatRelative(t *testing.T) {
+	// Use a catalog with localized messages
+	catalog := I18N_CATALOG
+	defer func() { I18N_CATALOG = catalog }()
+	I18N_CATALOG = NewCatalog()
+	I18N_CATALOG.Add("de", "format.reltime.now", Message{"other": "jetzt"})
+	I18N_CATALOG.Add("de", "format.reltime.minute.past", Message{"one": "vor {count} Minute", "other": "vor {count} Minuten"})
+	// Test
+	now := time.Date(2006, time.January, 2, 15, 4, 5, 0, time.UTC)
+	tests := []struct {
+		locale string
+		diff   time.Duration
+		want   string
+	}{
+		{"en", 0, "now"},
+		{"en", 500 * time.Millisecond, "now"},
+		{"en", time.Second, "in 1 second"},
+		{"en", -5 * time.Minute, "5 minutes ago"},
+		{"en", -time.Minute, "1 minute ago"},
+		{"en", 90 * time.Minute, "in 1 hour"},
+		{"en", -48 * time.Hour, "2 days ago"},
+		{"en", 45 * 24 * time.Hour, "in 1 month"},
+		{"en", -800 * 24 * time.Hour, "2 years ago"},
+		{"de", 0, "jetzt"},
+		{"de-AT", -time.Minute, "vor 1 Minute"},
+		{"de", -5 * time.Minute, "vor 5 Minuten"},
+		{"de", time.Hour, "in 1 hour"},
+	}
+	for _, tt := range tests {
+		if got := FormatRelative(tt.locale, now.Add(tt.diff), now); got != tt.want {
+			t.Errorf("FormatRelative(%q, %v) = %q, want %q", tt.locale, tt.diff, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,34 @@
+package i18n
+
+import (
+	"html/template"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// FuncMap holds a library predefined template functions.
+// You have to include it in your template building to use i18n properly.
+var FuncMap = template.FuncMap{
+	// t returns a message translated into the state context locale,
+	// using the global catalog (I18N_CATALOG).
+	// Arguments are name/value pairs, "count" selects the plural form.
+	//
+	//	{{ t . "cart.items" "count" .Count }}
+	"t": func(state component.State, key string, args ...any) string {
+		return T(component.ContextOf(state), key, args...)
+	},
+}
+
+// T returns a message translated into the context locale,
+// using the global catalog (I18N_CATALOG).
+func T(ctx *component.Context, key string, args ...any) string {
+	return I18N_CATALOG.Translate(localeOf(ctx), key, args...)
+}
+
+// localeOf resolves the context locale, falling back to I18N_FALLBACK.
+func localeOf(ctx *component.Context) string {
+	if ctx != nil && ctx.Locale != "" {
+		return ctx.Locale
+	}
+	return I18N_FALLBACK
+}
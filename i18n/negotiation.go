@@ -0,0 +1,109 @@
+package i18n
+
+import (
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// Negotiate resolves the request locale from supported ones.
+// Sources are checked in order: URL path prefix (f.e. /de/about),
+// locale cookie (I18N_COOKIE) and Accept-Language header.
+// Returns the fallback locale (I18N_FALLBACK) if nothing matches.
+func Negotiate(r *http.Request, supported []string) string {
+	// URL path prefix
+	if prefix, _, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/"); prefix != "" {
+		if locale, ok := match(prefix, supported, false); ok {
+			return locale
+		}
+	}
+	// Cookie
+	if cookie, err := r.Cookie(I18N_COOKIE); err == nil {
+		if locale, ok := match(cookie.Value, supported, true); ok {
+			return locale
+		}
+	}
+	// Accept-Language
+	for _, candidate := range accepted(r.Header.Get("Accept-Language")) {
+		if locale, ok := match(candidate, supported, true); ok {
+			return locale
+		}
+	}
+	// Fallback
+	return I18N_FALLBACK
+}
+
+// Middleware builds a rendering handler middleware,
+// which negotiates the request locale with catalog locales
+// and stores it in the context.
+// Response is varied by Accept-Language and Cookie headers,
+// so shared caches (f.e. cache.Middleware) don't mix locales.
+// Returned function is assignable to rendering.Middleware
+// (rendering package depends on i18n, so the type can't be referenced here).
+//
+//	rendering.HANDLER_MIDDLEWARES = append(rendering.HANDLER_MIDDLEWARES, i18n.Middleware(i18n.I18N_CATALOG))
+func Middleware(catalog *Catalog) func(ctx *component.Context, next func()) {
+	return func(ctx *component.Context, next func()) {
+		ctx.Locale = Negotiate(ctx.Request, catalog.Locales())
+		ctx.ResponseWriter.Header().Set("Content-Language", ctx.Locale)
+		ctx.ResponseWriter.Header().Add("Vary", "Accept-Language")
+		ctx.ResponseWriter.Header().Add("Vary", "Cookie")
+		next()
+	}
+}
+
+// match finds the candidate among supported locales.
+// If loose, base language match is allowed (f.e. "de-AT" matches "de").
+func match(candidate string, supported []string, loose bool) (string, bool) {
+	// Exact match
+	for _, locale := range supported {
+		if normalize(locale) == normalize(candidate) {
+			return locale, true
+		}
+	}
+	// Base language match
+	if loose {
+		for _, locale := range supported {
+			if base(locale) == base(candidate) {
+				return locale, true
+			}
+		}
+	}
+	return "", false
+}
+
+// accepted parses Accept-Language header into locales, ordered by quality.
+func accepted(header string) []string {
+	type entry struct {
+		locale  string
+		quality float64
+	}
+	entries := []entry{}
+	for _, part := range strings.Split(header, ",") {
+		// Parse locale and quality
+		params := strings.Split(part, ";")
+		e := entry{locale: strings.TrimSpace(params[0]), quality: 1}
+		for _, param := range params[1:] {
+			if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok && k == "q" {
+				if q, err := strconv.ParseFloat(v, 64); err == nil {
+					e.quality = q
+				}
+			}
+		}
+		if e.locale != "" && e.locale != "*" && e.quality > 0 {
+			entries = append(entries, e)
+		}
+	}
+	// Order by quality
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].quality > entries[j].quality
+	})
+	locales := make([]string, len(entries))
+	for i, e := range entries {
+		locales[i] = e.locale
+	}
+	return locales
+}
@@ -0,0 +1,69 @@
+package i18n
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+)
+
+func TestMiddlewareVary(t *testing.T) {
+	catalog := NewCatalog()
+	catalog.Add("de", "hello", Message{"other": "Hallo"})
+	w := httptest.NewRecorder()
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept-Language", "de-AT")
+	ctx := component.NewContext(w, r)
+	Middleware(catalog)(ctx, func() {})
+	if ctx.Locale != "de" {
+		t.Errorf("locale %q, want de", ctx.Locale)
+	}
+	vary := w.Header().Values("Vary")
+	if len(vary) != 2 || vary[0] != "Accept-Language" || vary[1] != "Cookie" {
+		t.Errorf("Vary %v, want [Accept-Language Cookie]", vary)
+	}
+}
+
+func TestNegotiate(t *testing.T) {
+	defer func(fallback string) { I18N_FALLBACK = fallback }(I18N_FALLBACK)
+	I18N_FALLBACK = "en"
+	supported := []string{"de", "de-at", "en", "fr"}
+	tests := []struct {
+		name   string
+		path   string
+		cookie string
+		accept string
+		want   string
+	}{
+		{"fallback", "/", "", "", "en"},
+		{"accept", "/", "", "fr", "fr"},
+		{"accept region", "/", "", "de-AT", "de-at"},
+		{"accept region to base", "/", "", "de-CH", "de"},
+		{"accept quality", "/", "", "en;q=0.5, fr;q=0.9, de;q=0.7", "fr"},
+		{"accept equal quality keeps order", "/", "", "de;q=0.8, fr;q=0.8", "de"},
+		{"accept zero quality", "/", "", "fr;q=0, de;q=0.1", "de"},
+		{"accept unsupported", "/", "", "ja, fr;q=0.1", "fr"},
+		{"accept wildcard", "/", "", "*", "en"},
+		{"accept malformed quality", "/", "", "fr;q=x, de;q=0.5", "fr"},
+		{"cookie", "/", "de", "fr", "de"},
+		{"cookie unsupported", "/", "ja", "fr", "fr"},
+		{"path", "/fr/about", "de", "de", "fr"},
+		{"path exact only", "/de-ch/about", "", "fr", "fr"},
+		{"path not a locale", "/about", "", "de", "de"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.path, nil)
+			if tt.cookie != "" {
+				r.AddCookie(&http.Cookie{Name: I18N_COOKIE, Value: tt.cookie})
+			}
+			if tt.accept != "" {
+				r.Header.Set("Accept-Language", tt.accept)
+			}
+			if got := Negotiate(r, supported); got != tt.want {
+				t.Errorf("Negotiate() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,122 @@
+package i18n
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// Category returns a CLDR plural category (one, two, few, many, other)
+// of the count for the locale.
+// Rules cover common languages with integer counts,
+// other languages are using English rules.
+func Category(locale string, count any) string {
+	// Resolve integer count
+	n, ok := integer(count)
+	if !ok {
+		return "other"
+	}
+	if n < 0 {
+		n = -n
+	}
+	n10, n100 := n%10, n%100
+	// Apply language rules
+	switch base(locale) {
+	case "ja", "zh", "ko", "th", "vi", "id", "ms", "tr":
+		return "other"
+	case "fr", "hy", "kab":
+		if n == 0 || n == 1 {
+			return "one"
+		}
+		return "other"
+	case "ru", "uk", "be", "sr", "hr", "bs":
+		switch {
+		case n10 == 1 && n100 != 11:
+			return "one"
+		case n10 >= 2 && n10 <= 4 && (n100 < 12 || n100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "pl":
+		switch {
+		case n == 1:
+			return "one"
+		case n10 >= 2 && n10 <= 4 && (n100 < 12 || n100 > 14):
+			return "few"
+		default:
+			return "many"
+		}
+	case "cs", "sk":
+		switch {
+		case n == 1:
+			return "one"
+		case n >= 2 && n <= 4:
+			return "few"
+		default:
+			return "other"
+		}
+	case "ar":
+		switch {
+		case n == 0:
+			return "zero"
+		case n == 1:
+			return "one"
+		case n == 2:
+			return "two"
+		case n100 >= 3 && n100 <= 10:
+			return "few"
+		case n100 >= 11:
+			return "many"
+		default:
+			return "other"
+		}
+	default:
+		if n == 1 {
+			return "one"
+		}
+		return "other"
+	}
+}
+
+// integer converts count into integer, if it's a whole number.
+// Unsigned values above MaxInt64 are reduced, keeping them large
+// and keeping the last digits, which plural rules depend on.
+// Floats out of int64 range are not considered integers.
+func integer(count any) (int64, bool) {
+	switch count := count.(type) {
+	case int:
+		return int64(count), true
+	case int8:
+		return int64(count), true
+	case int16:
+		return int64(count), true
+	case int32:
+		return int64(count), true
+	case int64:
+		return count, true
+	case uint:
+		return integer(uint64(count))
+	case uint8:
+		return int64(count), true
+	case uint16:
+		return int64(count), true
+	case uint32:
+		return int64(count), true
+	case uint64:
+		if count > math.MaxInt64 {
+			return int64(1e18 + count%1e18), true
+		}
+		return int64(count), true
+	case float32:
+		return integer(float64(count))
+	case float64:
+		if count != math.Trunc(count) || math.Abs(count) >= math.MaxInt64 {
+			return 0, false
+		}
+		return int64(count), true
+	default:
+		n, err := strconv.ParseInt(fmt.Sprint(count), 10, 64)
+		return n, err == nil
+	}
+}
@@ -0,0 +1,35 @@
+package i18n
+
+import (
+	"math"
+	"testing"
+)
+
+func TestCategory(t *testing.T) {
+	tests := []struct {
+		locale string
+		counts map[string][]any
+	}{
+		{"en", map[string][]any{"one": {1, -1, int8(1), uint64(1), 1.0, "1"}, "other": {0, 2, 11, 21, 1.5, "x", nil}}},
+		{"en", map[string][]any{"other": {uint64(math.MaxUint64), uint(math.MaxUint64), 1e300}}},
+		{"en-US", map[string][]any{"one": {1}, "other": {0, 2}}},
+		{"de", map[string][]any{"one": {1}, "other": {0, 2, 101}}},
+		{"fr", map[string][]any{"one": {0, 1}, "other": {2, 10}}},
+		{"ja", map[string][]any{"other": {0, 1, 2}}},
+		{"ru", map[string][]any{"one": {1, 21, 101}, "few": {2, 3, 4, 22, 104}, "many": {0, 5, 11, 12, 14, 25, 111}}},
+		{"ru", map[string][]any{"one": {uint64(math.MaxUint64 - 14)}, "many": {uint64(math.MaxUint64)}}},
+		{"uk", map[string][]any{"one": {31}, "few": {33}, "many": {13}}},
+		{"pl", map[string][]any{"one": {1}, "few": {2, 4, 22}, "many": {0, 5, 12, 21, 112}}},
+		{"cs", map[string][]any{"one": {1}, "few": {2, 4}, "other": {0, 5, 22}}},
+		{"ar", map[string][]any{"zero": {0}, "one": {1}, "two": {2}, "few": {3, 10, 103}, "many": {11, 99, 111}, "other": {100, 102}}},
+	}
+	for _, tt := range tests {
+		for want, counts := range tt.counts {
+			for _, count := range counts {
+				if got := Category(tt.locale, count); got != want {
+					t.Errorf("Category(%q, %#v) = %q, want %q", tt.locale, count, got, want)
+				}
+			}
+		}
+	}
+}
@@ -0,0 +1,103 @@
+package kyototest
+
+import (
+	"io"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// Node is a simplified DOM node.
+// Document node has an empty tag.
+type Node struct {
+	Tag      string            // Element tag name (lowercase), empty for text and document nodes
+	Attrs    map[string]string // Element attributes
+	Data     string            // Text node contents
+	Children []*Node           // Child nodes
+	Parent   *Node             // Parent node, nil for document
+}
+
+// Parse parses HTML markup into a simplified DOM.
+// Markup is parsed as HTML5 document (golang.org/x/net/html),
+// so missing html, head and body elements are implied
+// (as well as other implied elements, f.e. tbody).
+// Comments and doctype are omitted.
+func Parse(r io.Reader) (*Node, error) {
+	root, err := html.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+	return convert(root, nil), nil
+}
+
+// convert converts a parsed node into a simplified one.
+func convert(n *html.Node, parent *Node) *Node {
+	node := &Node{Parent: parent}
+	switch n.Type {
+	case html.ElementNode:
+		node.Tag = n.Data
+		node.Attrs = make(map[string]string, len(n.Attr))
+		for _, attr := range n.Attr {
+			key := attr.Key
+			if attr.Namespace != "" {
+				key = attr.Namespace + ":" + key
+			}
+			node.Attrs[key] = attr.Val
+		}
+	case html.TextNode:
+		node.Data = n.Data
+		return node
+	}
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode || child.Type == html.TextNode {
+			node.Children = append(node.Children, convert(child, node))
+		}
+	}
+	return node
+}
+
+// Attr returns an attribute value.
+func (n *Node) Attr(name string) (string, bool) {
+	value, ok := n.Attrs[name]
+	return value, ok
+}
+
+// Text returns concatenated text contents of the node.
+func (n *Node) Text() string {
+	if n.Tag == "" && n.Children == nil {
+		return n.Data
+	}
+	var text strings.Builder
+	for _, child := range n.Children {
+		text.WriteString(child.Text())
+	}
+	return text.String()
+}
+
+// Find returns all descendant nodes, matching the selector.
+// Supported selectors: tag, #id, .class, [attr], [attr=value],
+// their compounds, descendant (space) and child (>) combinators, groups (comma).
+func (n *Node) Find(selector string) []*Node {
+	groups := parseSelector(selector)
+	found := []*Node{}
+	n.walk(func(node *Node) {
+		for _, group := range groups {
+			if group.match(node) {
+				found = append(found, node)
+				return
+			}
+		}
+	})
+	return found
+}
+
+// walk visits all descendant elements in document order.
+func (n *Node) walk(visit func(node *Node)) {
+	for _, child := range n.Children {
+		if child.Tag == "" {
+			continue
+		}
+		visit(child)
+		child.walk(visit)
+	}
+}
@@ -0,0 +1,109 @@
+package kyototest
+
+import (
+	"strings"
+	"testing"
+)
+
+// parse parses markup or fails the test.
+func parse(t *testing.T, markup string) *Node {
+	t.Helper()
+	document, err := Parse(strings.NewReader(markup))
+	if err != nil {
+		t.Fatalf("parse %q: %v", markup, err)
+	}
+	return document
+}
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		selector string
+		count    int
+	}{
+		{"implicit li", `<ul><li class="a">one<li class="b">two</ul>`, "ul > li", 2},
+		{"implicit li nesting", `<ul><li class="a">one<li class="b">two</ul>`, "li li", 0},
+		{"nested lists", `<ul><li>a<ul><li>b</ul><li>c</ul>`, "ul > li", 3},
+		{"nested lists depth", `<ul><li>a<ul><li>b</ul><li>c</ul>`, "li li", 1},
+		{"implicit p", `<body><p>x<div id=y>z</div></body>`, "body > div", 1},
+		{"implicit p nesting", `<body><p>x<p>y</body>`, "p p", 0},
+		{"implicit td", `<table><tr><td>1<td>2<tr><td>3</table>`, "tr > td", 3},
+		{"implicit tr", `<table><tr><td>1<td>2<tr><td>3</table>`, "table > tbody > tr", 2},
+		{"implicit td nesting", `<table><tr><td>1<td>2<tr><td>3</table>`, "td td, tr tr", 0},
+		{"implicit option", `<select><option>a<option>b</select>`, "select > option", 2},
+		{"void", `<input disabled><span>after</span>`, "input span", 0},
+		{"void attribute", `<input disabled><span>after</span>`, "input[disabled]", 1},
+		{"void br", `<p>a<br>b<br>c</p><span></span>`, "br span", 0},
+		{"self-closing", `<br/><img src="a.png"/><span>after</span>`, "img[src='a.png'], br span, img span", 1},
+		{"self-closing sibling", `<br/><img src="a.png"/><span>after</span>`, "body > span", 1},
+		{"script", `<script>if (a < b && c > d) { x = "<div id=inner>" }</script><p id=after>`, "#inner", 0},
+		{"script sibling", `<script>if (a < b && c > d) { x = "<div id=inner>" }</script><p id=after>`, "script p", 0},
+		{"style", `<style>a > b { color: red }</style><p id=after>`, "#after", 1},
+		{"comment", `<!-- <div id=x> --><p>`, "#x", 0},
+		{"doctype", `<!DOCTYPE html><html><body><main></main></body></html>`, "html > body > main", 1},
+		{"unmatched end", `<div></span><p>x</p></div>`, "div > p", 1},
+		{"uppercase", `<DIV CLASS="A">x</DIV>`, "div.A", 1},
+		{"namespaced attribute", `<button hx-on:click="go()">x</button>`, "[hx-on:click]", 1},
+		{"alpine attribute", `<div @click="open = true" x-on:keyup.enter="go">x</div>`, "[x-on:keyup.enter]", 1},
+		{"less than in text", `<p>5 < 6</p><span></span>`, "body > span", 1},
+		{"textarea", `<textarea><b>x</b></textarea>`, "b", 0},
+		{"title", `<title><b>x</b></title>`, "head > title", 1},
+		{"implied document", `<p>x</p>`, "html > body > p", 1},
+		{"unquoted attribute", `<div data-id=42>x</div>`, "[data-id=42]", 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if found := parse(t, tt.markup).Find(tt.selector); len(found) != tt.count {
+				t.Errorf("Find(%q) found %d nodes, want %d", tt.selector, len(found), tt.count)
+			}
+		})
+	}
+}
+
+func TestParseText(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		selector string
+		text     string
+	}{
+		{"entities", `<p>a &amp; b&nbsp;c &lt;d&gt;</p>`, "p", "a & b c <d>"},
+		{"script", `<script>if (a < b && c) { x = "&amp;" }</script>`, "script", `if (a < b && c) { x = "&amp;" }`},
+		{"nested", `<div><b>bold</b> and <i>italic</i></div>`, "div", "bold and italic"},
+		{"implicit", `<ul><li>one<li>two</ul>`, "li", "one"},
+		{"less than", `<p>5 < 6</p>`, "p", "5 < 6"},
+		{"textarea", `<textarea><b>x</b> &amp;</textarea>`, "textarea", "<b>x</b> &"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			found := parse(t, tt.markup).Find(tt.selector)
+			if len(found) == 0 {
+				t.Fatalf("Find(%q) found nothing", tt.selector)
+			}
+			if text := found[0].Text(); text != tt.text {
+				t.Errorf("Text() = %q, want %q", text, tt.text)
+			}
+		})
+	}
+}
+
+func TestParseAttrs(t *testing.T) {
+	node := parse(t, `<a HREF="/x?a=1&amp;b=2" data-empty="" hidden @click="go">x</a>`).Find("a")[0]
+	tests := []struct {
+		name  string
+		value string
+		ok    bool
+	}{
+		{"href", "/x?a=1&b=2", true},
+		{"data-empty", "", true},
+		{"hidden", "", true},
+		{"@click", "go", true},
+		{"title", "", false},
+	}
+	for _, tt := range tests {
+		if value, ok := node.Attr(tt.name); value != tt.value || ok != tt.ok {
+			t.Errorf("Attr(%q) = %q, %v, want %q, %v", tt.name, value, ok, tt.value, tt.ok)
+		}
+	}
+}
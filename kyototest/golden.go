@@ -0,0 +1,31 @@
+package kyototest
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// Golden compares rendered markup with the golden file contents.
+// Set KYOTOTEST_UPDATE=1 environment variable to create or update golden files.
+func (r *Result) Golden(path string) *Result {
+	r.T.Helper()
+	// Update golden file, if requested
+	if os.Getenv("KYOTOTEST_UPDATE") != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			r.T.Fatalf("create golden directory: %v", err)
+		}
+		if err := os.WriteFile(path, []byte(r.HTML), 0644); err != nil {
+			r.T.Fatalf("write golden file: %v", err)
+		}
+		return r
+	}
+	// Compare
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		r.T.Fatalf("read golden file (run with KYOTOTEST_UPDATE=1 to create): %v", err)
+	}
+	if string(expected) != r.HTML {
+		r.T.Errorf("markup does not match golden file %s\n--- expected\n%s\n--- actual\n%s", path, expected, r.HTML)
+	}
+	return r
+}
@@ -0,0 +1,145 @@
+package kyototest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// Result is a component execution result.
+type Result struct {
+	T        testing.TB                 // Test, used for assertions
+	State    component.State            // Resulting component state
+	Response *httptest.ResponseRecorder // Recorded response
+	HTML     string                     // Rendered markup
+	document *Node                      // Parsed markup, lazy
+}
+
+// Run executes the component with rendering.Handler for provided request
+// and returns resulting state and rendered markup.
+func Run(t testing.TB, c component.Component, r *http.Request, middlewares ...rendering.Middleware) *Result {
+	t.Helper()
+	// Capture state
+	var state component.State
+	captured := func(ctx *component.Context) component.State {
+		state = c(ctx)
+		if state.GetName() == "" {
+			state.SetName(c.GetName())
+		}
+		return state
+	}
+	// Execute
+	rec := httptest.NewRecorder()
+	rendering.Handler(captured, middlewares...)(rec, r)
+	// Return
+	return &Result{
+		T:        t,
+		State:    state,
+		Response: rec,
+		HTML:     rec.Body.String(),
+	}
+}
+
+// Get executes the component with a fake GET request.
+func Get(t testing.TB, c component.Component, target string) *Result {
+	t.Helper()
+	return Run(t, c, httptest.NewRequest(http.MethodGet, target, nil))
+}
+
+// Post simulates htmx POST request to the component,
+// providing marshaled state as hx-state along with form values.
+func Post(t testing.TB, c component.Component, target string, state component.State, form url.Values) *Result {
+	t.Helper()
+	// Build form
+	values := url.Values{}
+	for k, v := range form {
+		values[k] = v
+	}
+	if state != nil {
+		values.Set("hx-state", state.Marshal(state))
+	}
+	// Build htmx request
+	r := httptest.NewRequest(http.MethodPost, target, strings.NewReader(values.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	r.Header.Set("HX-Request", "true")
+	// Execute
+	return Run(t, c, r)
+}
+
+// Document returns parsed markup.
+func (r *Result) Document() *Node {
+	if r.document == nil {
+		document, err := Parse(strings.NewReader(r.HTML))
+		if err != nil {
+			r.T.Fatalf("parse markup: %v", err)
+		}
+		r.document = document
+	}
+	return r.document
+}
+
+// Find returns all nodes, matching the selector.
+func (r *Result) Find(selector string) []*Node {
+	return r.Document().Find(selector)
+}
+
+// AssertStatus asserts the response status code.
+func (r *Result) AssertStatus(status int) *Result {
+	r.T.Helper()
+	if r.Response.Code != status {
+		r.T.Errorf("expected status %d, got %d", status, r.Response.Code)
+	}
+	return r
+}
+
+// AssertExists asserts at least one node matches the selector.
+func (r *Result) AssertExists(selector string) *Result {
+	r.T.Helper()
+	if len(r.Find(selector)) == 0 {
+		r.T.Errorf("expected %q to exist", selector)
+	}
+	return r
+}
+
+// AssertNotExists asserts no nodes match the selector.
+func (r *Result) AssertNotExists(selector string) *Result {
+	r.T.Helper()
+	if n := len(r.Find(selector)); n != 0 {
+		r.T.Errorf("expected %q not to exist, found %d", selector, n)
+	}
+	return r
+}
+
+// AssertText asserts the first node, matching the selector, has provided text
+// (whitespace is trimmed).
+func (r *Result) AssertText(selector, text string) *Result {
+	r.T.Helper()
+	nodes := r.Find(selector)
+	if len(nodes) == 0 {
+		r.T.Errorf("expected %q to exist", selector)
+		return r
+	}
+	if actual := strings.TrimSpace(nodes[0].Text()); actual != text {
+		r.T.Errorf("expected %q text %q, got %q", selector, text, actual)
+	}
+	return r
+}
+
+// AssertAttr asserts the first node, matching the selector, has provided attribute value.
+func (r *Result) AssertAttr(selector, attr, value string) *Result {
+	r.T.Helper()
+	nodes := r.Find(selector)
+	if len(nodes) == 0 {
+		r.T.Errorf("expected %q to exist", selector)
+		return r
+	}
+	if actual, ok := nodes[0].Attr(attr); !ok || actual != value {
+		r.T.Errorf("expected %q attribute %s=%q, got %q", selector, attr, value, actual)
+	}
+	return r
+}
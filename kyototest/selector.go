@@ -0,0 +1,186 @@
+package kyototest
+
+import (
+	"strings"
+)
+
+// attribute is an attribute selector.
+type attribute struct {
+	name     string
+	value    string
+	hasValue bool
+}
+
+// compound is a compound selector (f.e. div#id.class[attr]).
+type compound struct {
+	tag        string
+	id         string
+	classes    []string
+	attributes []attribute
+	combinator byte // Combinator with the previous compound (' ' or '>')
+}
+
+// selector is a complex selector, a chain of compounds.
+type selector []compound
+
+// parseSelector parses a selector group.
+func parseSelector(s string) []selector {
+	selectors := []selector{}
+	for _, group := range split(s, ',') {
+		sel := selector{}
+		combinator := byte(' ')
+		rest := strings.TrimSpace(group)
+		for rest != "" {
+			switch {
+			case rest[0] == ' ':
+				rest = rest[1:]
+			case rest[0] == '>':
+				combinator = '>'
+				rest = rest[1:]
+			default:
+				// Parse compound until the next combinator
+				end := 0
+				for depth := 0; end < len(rest); end++ {
+					if rest[end] == '[' {
+						depth++
+					} else if rest[end] == ']' {
+						depth--
+					} else if depth == 0 && (rest[end] == ' ' || rest[end] == '>') {
+						break
+					}
+				}
+				c := parseCompound(rest[:end])
+				c.combinator = combinator
+				sel = append(sel, c)
+				combinator = ' '
+				rest = rest[end:]
+			}
+		}
+		if len(sel) > 0 {
+			selectors = append(selectors, sel)
+		}
+	}
+	return selectors
+}
+
+// parseCompound parses a compound selector.
+func parseCompound(s string) compound {
+	c := compound{}
+	for s != "" {
+		// Resolve next token end
+		end := 1
+		if s[0] == '[' {
+			end = strings.IndexByte(s, ']') + 1
+			if end == 0 {
+				end = len(s)
+			}
+		} else {
+			for end < len(s) && !strings.ContainsRune("#.[", rune(s[end])) {
+				end++
+			}
+		}
+		token := s[:end]
+		s = s[end:]
+		// Apply token
+		switch token[0] {
+		case '#':
+			c.id = token[1:]
+		case '.':
+			c.classes = append(c.classes, token[1:])
+		case '[':
+			a := attribute{}
+			inner := strings.TrimSuffix(token[1:], "]")
+			if name, value, ok := strings.Cut(inner, "="); ok {
+				a.name, a.value, a.hasValue = strings.TrimSpace(name), strings.Trim(strings.TrimSpace(value), `"'`), true
+			} else {
+				a.name = strings.TrimSpace(inner)
+			}
+			c.attributes = append(c.attributes, a)
+		default:
+			if token != "*" {
+				c.tag = strings.ToLower(token)
+			}
+		}
+	}
+	return c
+}
+
+// split splits string by separator, ignoring separators inside brackets and quotes.
+func split(s string, sep byte) []string {
+	parts := []string{}
+	depth, quote, start := 0, byte(0), 0
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case s[i] == '[':
+			depth++
+		case s[i] == ']':
+			depth--
+		case s[i] == sep && depth == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// match checks if the node matches the selector.
+func (s selector) match(node *Node) bool {
+	return s.matchFrom(len(s)-1, node)
+}
+
+// matchFrom matches compounds from i-th to the first one, going up through ancestors.
+func (s selector) matchFrom(i int, node *Node) bool {
+	// Match current compound
+	if !s[i].match(node) {
+		return false
+	}
+	if i == 0 {
+		return true
+	}
+	// Match previous compound against ancestors
+	if s[i].combinator == '>' {
+		return node.Parent != nil && node.Parent.Tag != "" && s.matchFrom(i-1, node.Parent)
+	}
+	for p := node.Parent; p != nil && p.Tag != ""; p = p.Parent {
+		if s.matchFrom(i-1, p) {
+			return true
+		}
+	}
+	return false
+}
+
+// match checks if the node matches the compound.
+func (c compound) match(node *Node) bool {
+	if c.tag != "" && c.tag != node.Tag {
+		return false
+	}
+	if c.id != "" && node.Attrs["id"] != c.id {
+		return false
+	}
+	classes := strings.Fields(node.Attrs["class"])
+	for _, class := range c.classes {
+		found := false
+		for _, candidate := range classes {
+			if candidate == class {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	for _, a := range c.attributes {
+		value, ok := node.Attrs[a.name]
+		if !ok || (a.hasValue && value != a.value) {
+			return false
+		}
+	}
+	return true
+}
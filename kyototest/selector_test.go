@@ -0,0 +1,64 @@
+package kyototest
+
+import (
+	"strings"
+	"testing"
+)
+
+const selectorMarkup = `
+<main id="main">
+	<h1 class="title">Cart</h1>
+	<div class="card primary" data-id="1">
+		<div class="body"><p>first</p></div>
+	</div>
+	<div class="card" data-id="2" data-label="two words">
+		<section><div class="body"><p>second</p></div></section>
+	</div>
+	<ul><li>a</li><li class="active">b</li></ul>
+	<h2>Total</h2>
+</main>
+`
+
+func TestFind(t *testing.T) {
+	document := parse(t, selectorMarkup)
+	tests := []struct {
+		selector string
+		texts    []string
+	}{
+		// Simple
+		{"h1", []string{"Cart"}},
+		{"#main > h2", []string{"Total"}},
+		{".active", []string{"b"}},
+		{"LI", []string{"a", "b"}},
+		// Compound
+		{".card.primary p", []string{"first"}},
+		{"div.card[data-id='2'] p", []string{"second"}},
+		{"li.active.missing", nil},
+		// Attributes
+		{"[data-label]", []string{"second"}},
+		{`[data-label="two words"] p`, []string{"second"}},
+		{"[data-id=3]", nil},
+		// Combinators
+		{".card > .body > p", []string{"first"}},
+		{".card .body p", []string{"first", "second"}},
+		{".card > section > .body p", []string{"second"}},
+		{"main>ul>li", []string{"a", "b"}},
+		{"main > li", nil},
+		// Groups, in document order
+		{"h2, h1", []string{"Cart", "Total"}},
+		{"h1, .title", []string{"Cart"}},
+		// Universal
+		{"ul > *", []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			texts := []string{}
+			for _, node := range document.Find(tt.selector) {
+				texts = append(texts, strings.TrimSpace(node.Text()))
+			}
+			if strings.Join(texts, "|") != strings.Join(tt.texts, "|") {
+				t.Errorf("Find(%q) = %q, want %q", tt.selector, texts, tt.texts)
+			}
+		})
+	}
+}
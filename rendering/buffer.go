@@ -0,0 +1,102 @@
+package rendering
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"sync"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// Global buffering configuration.
+var (
+	// Buffer output of all handlers before writing, false by default.
+	// Streamed components are not affected, streaming and buffering are mutually exclusive.
+	HANDLER_BUFFERED = false
+	// Error page, written in buffered mode if rendering fails.
+	// Default one logs the error and writes a generic 500 response.
+	HANDLER_ERROR = func(ctx *component.Context, err error) {
+		log.Printf("kyoto: rendering %s failed: %v", ctx.Request.URL.Path, err)
+		http.Error(ctx.ResponseWriter, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+	}
+)
+
+// Bufferer defines an optional buffering requirement for rendering implementations.
+// Buffered renderer commits status, headers and body only after rendering succeeded,
+// falling back to the error page (HANDLER_ERROR) on failure.
+type Bufferer interface {
+	// Define if rendering must to be buffered.
+	RenderBuffer() bool
+}
+
+// buffers is a rendering buffers pool.
+var buffers = sync.Pool{
+	New: func() any {
+		return &bytes.Buffer{}
+	},
+}
+
+// getBuffer returns a clean buffer from the pool.
+func getBuffer() *bytes.Buffer {
+	buffer := buffers.Get().(*bytes.Buffer)
+	buffer.Reset()
+	return buffer
+}
+
+// putBuffer returns a buffer into the pool.
+func putBuffer(buffer *bytes.Buffer) {
+	buffers.Put(buffer)
+}
+
+// buffered checks if the state must be rendered in buffered mode.
+// Streaming takes precedence over global buffering,
+// explicit buffering of a streamed state is a misuse.
+func buffered(state component.State) bool {
+	s, ok := state.(Streamer)
+	streamed := ok && s.RenderStream()
+	b, ok := state.(Bufferer)
+	buffer := ok && b.RenderBuffer()
+	if streamed && buffer {
+		panic("component can't be both streamed and buffered")
+	}
+	return !streamed && (buffer || HANDLER_BUFFERED)
+}
+
+// renderBuffered renders the state into buffer
+// and writes it only on success.
+// Returns false if rendering failed and the error page was written instead.
+func renderBuffered(ctx *component.Context, state component.State) bool {
+	// Get buffer
+	buffer := getBuffer()
+	defer putBuffer(buffer)
+	// Render into buffer
+	if err := renderSafe(state, buffer); err != nil {
+		HANDLER_ERROR(ctx, err)
+		return false
+	}
+	// Commit
+	writeStatus(ctx)
+	if _, err := buffer.WriteTo(ctx.ResponseWriter); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// renderSafe renders the state, recovering panics into error.
+func renderSafe(state component.State, buffer *bytes.Buffer) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	return state.(Renderer).Render(state, buffer)
+}
+
+// writeStatus writes status code of the context, if it was set.
+func writeStatus(ctx *component.Context) {
+	if status := ctx.Status(); status != 0 {
+		ctx.ResponseWriter.WriteHeader(status)
+	}
+}
@@ -0,0 +1,90 @@
+package rendering
+
+import (
+	"bytes"
+	"html/template"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type bufferState struct {
+	component.Disposable
+	Template
+}
+
+func TestBuffered(t *testing.T) {
+	defer func(global bool) { HANDLER_BUFFERED = global }(HANDLER_BUFFERED)
+	tests := []struct {
+		name   string
+		global bool
+		stream bool
+		buffer bool
+		want   bool
+	}{
+		{"default", false, false, false, false},
+		{"component", false, false, true, true},
+		{"global", true, false, false, true},
+		{"global streamed", true, true, false, false},
+		{"streamed", false, true, false, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			HANDLER_BUFFERED = tt.global
+			state := &bufferState{}
+			state.Template.Stream, state.Template.Buffer = tt.stream, tt.buffer
+			if got := buffered(state); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBufferedStreamed(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("streamed and buffered state doesn't panic")
+		}
+	}()
+	state := &bufferState{}
+	state.Template.Stream, state.Template.Buffer = true, true
+	buffered(state)
+}
+
+type bufferFailing struct {
+	bufferState
+
+	after bool
+}
+
+func (s *bufferFailing) AfterRender(ctx *component.Context) {
+	s.after = true
+}
+
+func TestBufferedError(t *testing.T) {
+	// Capture log
+	var logged bytes.Buffer
+	log.SetOutput(&logged)
+	defer log.SetOutput(os.Stderr)
+	// Render failing state
+	state := &bufferFailing{}
+	state.Template.Raw = template.Must(template.New("page").Parse(`<p>{{ .Missing }}</p>`))
+	state.Template.Buffer = true
+	w := httptest.NewRecorder()
+	Handler(func(ctx *component.Context) component.State { return state })(w, httptest.NewRequest("GET", "/page", nil))
+	// Check
+	if w.Code != http.StatusInternalServerError {
+		t.Errorf("status %d, want 500", w.Code)
+	}
+	if !strings.Contains(logged.String(), "/page") || !strings.Contains(logged.String(), "Missing") {
+		t.Errorf("error is not logged: %q", logged.String())
+	}
+	if state.after {
+		t.Error("AfterRender is called after the error page")
+	}
+}
@@ -0,0 +1,116 @@
+package rendering
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// Conditioner defines an optional conditional requests requirement.
+type Conditioner interface {
+	// Provide conditional requests configuration.
+	RenderConditional() *Conditional
+}
+
+// Conditional enables conditional GET support for the component.
+// Nest it into the component state to opt-in.
+// With ETag enabled, response is buffered and hashed,
+// so handler responds with 304 Not Modified when client already has the same output.
+// With Version provided, it's used as ETag and rendering is skipped entirely for matching requests.
+// Without hashing, output is rendered with buffering and streaming options of the state.
+// Hashing buffers the output itself, so it can't be combined with streaming.
+type Conditional struct {
+	ETag         bool      `json:"-"` // Compute ETag from rendered output, false by default
+	Version      string    `json:"-"` // State-provided version, used as ETag instead of hashing
+	LastModified time.Time `json:"-"` // Last modification time, zero by default
+	CacheControl string    `json:"-"` // Cache-Control header value, empty by default
+}
+
+func (c *Conditional) RenderConditional() *Conditional {
+	return c
+}
+
+// renderConditional renders the state with conditional requests support.
+// Returns false if rendering failed and the error page was written instead.
+func renderConditional(ctx *component.Context, state component.State, c *Conditional) bool {
+	w, r := ctx.ResponseWriter, ctx.Request
+	// Validate
+	if s, ok := state.(Streamer); ok && s.RenderStream() && c.ETag && c.Version == "" {
+		panic("component can't be both streamed and hashed (use Conditional.Version instead of ETag)")
+	}
+	// Set caching headers
+	if c.CacheControl != "" {
+		w.Header().Set("Cache-Control", c.CacheControl)
+	}
+	if !c.LastModified.IsZero() {
+		w.Header().Set("Last-Modified", c.LastModified.UTC().Format(http.TimeFormat))
+	}
+	// Conditional requests are applicable only for safe methods and successful responses
+	conditional := (r.Method == http.MethodGet || r.Method == http.MethodHead) &&
+		(ctx.Status() == 0 || ctx.Status() == http.StatusOK)
+	// Check state-provided version before rendering
+	if c.Version != "" {
+		w.Header().Set("ETag", `"`+c.Version+`"`)
+		if conditional && notModified(r, `"`+c.Version+`"`, c.LastModified) {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	} else if !c.ETag && conditional && notModified(r, "", c.LastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	// Render as usual, if hashing is not needed
+	if c.Version != "" || !c.ETag {
+		return renderOutput(ctx, state)
+	}
+	// Render into buffer
+	buffer := getBuffer()
+	defer putBuffer(buffer)
+	if err := renderSafe(state, buffer); err != nil {
+		HANDLER_ERROR(ctx, err)
+		return false
+	}
+	// Hash output
+	sum := sha256.Sum256(buffer.Bytes())
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+	w.Header().Set("ETag", etag)
+	if conditional && notModified(r, etag, c.LastModified) {
+		w.WriteHeader(http.StatusNotModified)
+		return true
+	}
+	// Write
+	writeStatus(ctx)
+	if _, err := buffer.WriteTo(w); err != nil {
+		panic(err)
+	}
+	return true
+}
+
+// notModified checks request preconditions against ETag and modification time.
+// If-None-Match takes precedence over If-Modified-Since.
+func notModified(r *http.Request, etag string, modified time.Time) bool {
+	// Check ETag
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		if etag == "" {
+			return false
+		}
+		for _, candidate := range strings.Split(inm, ",") {
+			candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+			if candidate == "*" || candidate == etag {
+				return true
+			}
+		}
+		return false
+	}
+	// Check modification time
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" && !modified.IsZero() {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !modified.Truncate(time.Second).After(t)
+		}
+	}
+	return false
+}
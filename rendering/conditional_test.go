@@ -0,0 +1,82 @@
+package rendering
+
+import (
+	"html/template"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type conditionalState struct {
+	component.Disposable
+	Template
+	Conditional
+
+	Child component.Future
+}
+
+func TestConditionalModes(t *testing.T) {
+	slow := func(ctx *component.Context) component.State {
+		time.Sleep(5 * time.Millisecond)
+		return &streamLeaf{Func: Func{Writer: func(w io.Writer) error {
+			_, err := io.WriteString(w, "child")
+			return err
+		}}}
+	}
+	tests := []struct {
+		name    string
+		version string
+		stream  bool
+		buffer  bool
+		fail    bool
+		want    string
+		status  int
+	}{
+		{"version streamed", "v1", true, false, false, `data-kyoto-chunk`, http.StatusOK},
+		{"version buffered failure", "v1", false, true, true, "Internal Server Error", http.StatusInternalServerError},
+		{"plain buffered failure", "", false, true, true, "Internal Server Error", http.StatusInternalServerError},
+		{"plain direct", "", false, false, false, "<p>child</p>", http.StatusOK},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page := func(ctx *component.Context) component.State {
+				state := &conditionalState{}
+				markup := `<p>{{ render .Child }}</p>`
+				if tt.fail {
+					markup = `<p>{{ render .Child }}{{ .Missing }}</p>`
+				}
+				state.Template.Raw = template.Must(template.New("page").Funcs(FuncMap).Parse(markup))
+				state.Template.Stream, state.Template.Buffer = tt.stream, tt.buffer
+				state.Conditional.Version = tt.version
+				state.Child = component.Use(ctx, slow)
+				return state
+			}
+			w := httptest.NewRecorder()
+			Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+			if w.Code != tt.status {
+				t.Errorf("status %d, want %d", w.Code, tt.status)
+			}
+			if !strings.Contains(w.Body.String(), tt.want) {
+				t.Errorf("body %q doesn't contain %q", w.Body.String(), tt.want)
+			}
+		})
+	}
+}
+
+func TestConditionalStreamedETag(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("streamed and hashed state doesn't panic")
+		}
+	}()
+	state := &conditionalState{}
+	state.Template.Stream = true
+	state.Conditional.ETag = true
+	w := httptest.NewRecorder()
+	renderConditional(component.NewContext(w, httptest.NewRequest("GET", "/", nil)), state, &state.Conditional)
+}
@@ -1,12 +1,16 @@
 package rendering
 
 import (
+	"fmt"
 	"go.kyoto.codes/v3/htmx"
 	"html/template"
 	"strings"
 
+	"go.kyoto.codes/v3/assets"
 	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/i18n"
 	"go.kyoto.codes/zen/v3/errorsx"
+	"go.kyoto.codes/zen/v3/logic"
 	"go.kyoto.codes/zen/v3/mapx"
 )
 
@@ -16,19 +20,73 @@ var FuncMap = template.FuncMap{
 	// Inline render function.
 	// Allows to avoid explicit template syntax
 	// and customize render behavior.
-	"render": func(f component.Future) template.HTML {
-		// Await future
-		state := f()
-		// Check if state implements render
-		if r, ok := state.(Renderer); ok {
-			// Render
-			var out strings.Builder
-			errorsx.Must(0, r.Render(state, &out))
-			// Pack and return
-			return template.HTML(out.String())
+	// Slots content may be provided with `fill` function.
+	"render": func(f component.Future, fills ...Fill) template.HTML {
+		// Await future, deferring it in case of streaming
+		state := component.Defer(f)
+		// Fill slots.
+		// Deferred states are filled when resolved,
+		// lazy states are loaded with a separate request, so fills are not applicable.
+		if len(fills) > 0 {
+			switch s := state.(type) {
+			case *component.Pending:
+				s.Prepare(func(state component.State) { fill(state, fills) })
+			case *component.Lazy:
+			default:
+				fill(state, fills)
+			}
 		}
-		// Panic if state does not implement render
-		panic("state does not implement render")
+		// Render
+		return errorsx.Must(render(state))
+	},
+	// Slot function returns a named slot content of the state.
+	"slot": func(state Slotter, name string) template.HTML {
+		return state.GetSlot(name)
+	},
+	// Fill function builds slots content from name/content pairs.
+	// Content might be a markup (f.e. `include` output) or a plain string.
+	"fill": func(pairs ...any) Fill {
+		// Validate
+		if len(pairs)%2 != 0 {
+			panic("fill requires name/content pairs")
+		}
+		// Build
+		fill := Fill{}
+		for i := 0; i < len(pairs); i += 2 {
+			name := pairs[i].(string)
+			switch content := pairs[i+1].(type) {
+			case template.HTML:
+				fill[name] = content
+			default:
+				fill[name] = template.HTML(template.HTMLEscapeString(fmt.Sprint(content)))
+			}
+		}
+		return fill
+	},
+	// Include function renders a named template into markup.
+	// It's available only with rendering.Template (without Raw),
+	// this declaration is a placeholder for template parsing.
+	"include": func(name string, data any) template.HTML {
+		panic("include is available only within rendering.Template")
+	},
+	// Head elements function.
+	// Awaits for all components of the state context
+	// and returns collected and deduplicated head elements.
+	// In case of streaming, it doesn't wait, so the shell is flushed right away,
+	// and deferred components are excluded.
+	// Use it inside of <head> in your page layout.
+	"head": func(state component.State) template.HTML {
+		// Resolve bound context
+		ctx := component.ContextOf(state)
+		if ctx == nil || ctx.Head == nil {
+			return ""
+		}
+		// Wait for components to contribute, unless streaming
+		if ctx.GetStream() == nil {
+			ctx.Wait()
+		}
+		// Render
+		return ctx.Head.Render()
 	},
 }
 
@@ -37,4 +95,53 @@ var FuncMapAll = mapx.Merge(
 	FuncMap,
 	htmx.FuncMap,
 	component.FuncMap,
+	assets.FuncMap,
+	i18n.FuncMap,
+	i18n.FormatFuncMap,
 )
+
+// fill fills state slots with provided content.
+func fill(state component.State, fills []Fill) {
+	slotter, ok := state.(Slotter)
+	if !ok {
+		panic("state does not implement slots")
+	}
+	for _, f := range fills {
+		for name, content := range f {
+			slotter.SetSlot(name, content)
+		}
+	}
+}
+
+// render renders a component state into html.
+// Deferred (pending) and lazy states are rendered as placeholders.
+func render(state component.State) (template.HTML, error) {
+	// Render placeholder for pending state
+	if p, ok := state.(*component.Pending); ok {
+		return template.HTML(fmt.Sprintf(`<div id="kyoto-pending-%s" style="display:contents"></div>`, p.ID)), nil
+	}
+	// Render loader for lazy state
+	if l, ok := state.(*component.Lazy); ok {
+		return template.HTML(fmt.Sprintf(
+			`<div hx-get="%s" hx-trigger="%s" hx-swap="outerHTML">%s</div>`,
+			template.HTMLEscapeString(l.Endpoint),
+			template.HTMLEscapeString(logic.Or(l.Trigger, "load")),
+			l.Loading)), nil
+	}
+	// Check if state implements render
+	if r, ok := state.(Renderer); ok {
+		// Resolve bound context for hooks
+		ctx := component.ContextOf(state)
+		// Render
+		var out strings.Builder
+		component.BeforeRender(ctx, state)
+		if err := r.Render(state, &out); err != nil {
+			return "", err
+		}
+		component.AfterRender(ctx, state)
+		// Pack and return
+		return template.HTML(out.String()), nil
+	}
+	// Panic if state does not implement render
+	panic("state does not implement render")
+}
@@ -7,27 +7,90 @@ import (
 )
 
 // Handler builds a http.HandlerFunc that renders provided component.
-func Handler(c component.Component) http.HandlerFunc {
+// Provided middlewares are applied after global ones (HANDLER_MIDDLEWARES).
+// Check component package for the lifecycle hooks invocation order.
+func Handler(c component.Component, middlewares ...Middleware) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		// Create context
 		ctx := component.NewContext(w, r)
-		// Build page state tree
-		state := c(ctx)
-		// Inject component name, unless it's already set
-		if state.GetName() == "" {
-			state.SetName(c.GetName())
-		}
-		// Ensure state implements render
-		if _, ok := state.(Renderer); !ok {
-			panic("The component does not implement rendering")
-		}
-		// Check if we need to skip rendering
-		if state.(Renderer).RenderSkip() {
+		// Compose middlewares
+		chained := make([]Middleware, 0, len(HANDLER_MIDDLEWARES)+len(middlewares))
+		chained = append(chained, HANDLER_MIDDLEWARES...)
+		chained = append(chained, middlewares...)
+		// Execute middlewares and handle
+		chain(ctx, chained, func() {
+			handle(ctx, c)
+		})
+	}
+}
+
+// handle builds and renders provided component within the context.
+func handle(ctx *component.Context, c component.Component) {
+	// Build page state tree
+	state := c(ctx)
+	// Inject component name, unless it's already set
+	if state.GetName() == "" {
+		state.SetName(c.GetName())
+	}
+	// Bind state to the context and initialize
+	component.Bind(ctx, state)
+	component.Init(ctx, state)
+	// Ensure state implements render
+	if _, ok := state.(Renderer); !ok {
+		panic("The component does not implement rendering")
+	}
+	// Check if we need to skip rendering
+	if state.(Renderer).RenderSkip() {
+		return
+	}
+	// Render JSON, if opted-in and requested
+	if j, ok := state.(JSONRenderer); ok && j.RenderJSON() {
+		ctx.ResponseWriter.Header().Add("Vary", "Accept")
+		if acceptsJSON(ctx.Request) {
+			component.BeforeRender(ctx, state)
+			if err := renderJSON(ctx, state); err != nil {
+				panic(err)
+			}
+			component.AfterRender(ctx, state)
 			return
 		}
-		// Render
-		if err := state.(Renderer).Render(state, ctx.ResponseWriter); err != nil {
-			panic(err)
-		}
 	}
+	// Set content type, if provided
+	if ct, ok := state.(ContentTyper); ok && ctx.ResponseWriter.Header().Get("Content-Type") == "" {
+		ctx.ResponseWriter.Header().Set("Content-Type", ct.RenderContentType())
+	}
+	// Notify state before rendering
+	component.BeforeRender(ctx, state)
+	// Render, with conditional requests support if opted-in
+	var rendered bool
+	if c, ok := state.(Conditioner); ok {
+		rendered = renderConditional(ctx, state, c.RenderConditional())
+	} else {
+		rendered = renderOutput(ctx, state)
+	}
+	// Notify state after rendering, unless the error page was written instead
+	if rendered {
+		component.AfterRender(ctx, state)
+	}
+}
+
+// renderOutput renders the state in buffered, streaming or direct mode.
+// Returns false if rendering failed and the error page was written instead.
+func renderOutput(ctx *component.Context, state component.State) bool {
+	// Render buffered, if requested
+	if buffered(state) {
+		return renderBuffered(ctx, state)
+	}
+	// Stream, if requested
+	if s, ok := state.(Streamer); ok && s.RenderStream() {
+		writeStatus(ctx)
+		renderStream(ctx, state)
+		return true
+	}
+	// Render
+	writeStatus(ctx)
+	if err := state.(Renderer).Render(state, ctx.ResponseWriter); err != nil {
+		panic(err)
+	}
+	return true
 }
@@ -0,0 +1,30 @@
+package rendering
+
+import (
+	"go.kyoto.codes/v3/component"
+)
+
+// Global middleware configuration.
+// Global middlewares are applied to each handler, before handler-specific ones.
+var (
+	HANDLER_MIDDLEWARES []Middleware
+)
+
+// Middleware is a rendering handler middleware.
+// It operates on the context before the component is executed.
+// Call next to proceed, or don't call it to short-circuit (redirect, forbidden, etc.).
+// To wrap the rendered output, replace ctx.ResponseWriter before calling next.
+type Middleware func(ctx *component.Context, next func())
+
+// chain executes middlewares one by one, with the handler in the end.
+func chain(ctx *component.Context, middlewares []Middleware, handler func()) {
+	// Execute handler if no middlewares left
+	if len(middlewares) == 0 {
+		handler()
+		return
+	}
+	// Execute middleware
+	middlewares[0](ctx, func() {
+		chain(ctx, middlewares[1:], handler)
+	})
+}
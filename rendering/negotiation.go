@@ -0,0 +1,82 @@
+package rendering
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// JSONRenderer defines an optional JSON rendering requirement.
+type JSONRenderer interface {
+	// Define if state may be rendered as JSON on client request.
+	RenderJSON() bool
+}
+
+// JSON enables content negotiation for the component.
+// Nest it into the component state to opt-in.
+// Requests with Accept: application/json (or ?format=json)
+// will get JSON-serialized state instead of rendered markup.
+// Serialization respects json tags, so rendering fields are excluded.
+type JSON struct{}
+
+func (*JSON) RenderJSON() bool {
+	return true
+}
+
+// acceptsJSON checks if the request prefers JSON over HTML.
+// htmx requests (HX-Request) always get HTML, because markup is swapped.
+func acceptsJSON(r *http.Request) bool {
+	// htmx request
+	if r.Header.Get("HX-Request") != "" {
+		return false
+	}
+	// Explicit format
+	if format := r.URL.Query().Get("format"); format != "" {
+		return format == "json"
+	}
+	// Compare Accept header qualities,
+	// earlier entry wins on equal quality
+	jsonq, htmlq := -1.0, -1.0
+	jsonFirst := false
+	for _, entry := range strings.Split(r.Header.Get("Accept"), ",") {
+		// Parse media type and quality
+		params := strings.Split(entry, ";")
+		media := strings.TrimSpace(params[0])
+		quality := 1.0
+		for _, param := range params[1:] {
+			if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok && k == "q" {
+				if q, err := strconv.ParseFloat(v, 64); err == nil {
+					quality = q
+				}
+			}
+		}
+		// Store
+		switch {
+		case (media == "application/json" || strings.HasSuffix(media, "+json")) && jsonq < 0:
+			jsonq = quality
+			jsonFirst = htmlq < 0
+		case (media == "text/html" || media == "*/*") && htmlq < 0:
+			htmlq = quality
+		}
+	}
+	return jsonq > 0 && (jsonq > htmlq || (jsonq == htmlq && jsonFirst))
+}
+
+// renderJSON writes JSON-serialized state.
+// Headers are set before the status is written,
+// and nothing is written if serialization fails.
+func renderJSON(ctx *component.Context, state component.State) error {
+	// Serialize
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	// Write
+	ctx.ResponseWriter.Header().Set("Content-Type", "application/json")
+	writeStatus(ctx)
+	_, err = ctx.ResponseWriter.Write(append(data, '\n'))
+	return err
+}
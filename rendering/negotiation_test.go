@@ -0,0 +1,89 @@
+package rendering
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type negotiationState struct {
+	component.Disposable
+	Func
+	JSON
+
+	Title string
+}
+
+func TestAcceptsJSON(t *testing.T) {
+	tests := []struct {
+		name   string
+		target string
+		header http.Header
+		want   bool
+	}{
+		{"no accept", "/", nil, false},
+		{"html", "/", http.Header{"Accept": {"text/html"}}, false},
+		{"json", "/", http.Header{"Accept": {"application/json"}}, true},
+		{"json suffix", "/", http.Header{"Accept": {"application/vnd.api+json"}}, true},
+		{"browser", "/", http.Header{"Accept": {"text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8"}}, false},
+		{"any", "/", http.Header{"Accept": {"*/*"}}, false},
+		{"json over any", "/", http.Header{"Accept": {"application/json, */*;q=0.1"}}, true},
+		{"any over json", "/", http.Header{"Accept": {"*/*, application/json;q=0.5"}}, false},
+		{"html quality", "/", http.Header{"Accept": {"text/html;q=0.5, application/json;q=0.9"}}, true},
+		{"equal quality, json first", "/", http.Header{"Accept": {"application/json, text/html"}}, true},
+		{"equal quality, html first", "/", http.Header{"Accept": {"text/html, application/json"}}, false},
+		{"zero quality", "/", http.Header{"Accept": {"application/json;q=0"}}, false},
+		{"format json", "/?format=json", http.Header{"Accept": {"text/html"}}, true},
+		{"format html", "/?format=html", http.Header{"Accept": {"application/json"}}, false},
+		{"htmx", "/", http.Header{"Accept": {"application/json"}, "Hx-Request": {"true"}}, false},
+		{"htmx format", "/?format=json", http.Header{"Hx-Request": {"true"}}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", tt.target, nil)
+			r.Header = tt.header
+			if r.Header == nil {
+				r.Header = http.Header{}
+			}
+			if got := acceptsJSON(r); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderJSONName(t *testing.T) {
+	page := func(ctx *component.Context) component.State {
+		return &negotiationState{Title: "page"}
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	Handler(page)(w, r)
+	if body := w.Body.String(); body != "{\"Title\":\"page\"}\n" {
+		t.Errorf("body %q, component name must not leak", body)
+	}
+}
+
+func TestRenderJSONStatus(t *testing.T) {
+	page := func(ctx *component.Context) component.State {
+		ctx.SetStatus(http.StatusNotFound)
+		return &negotiationState{Title: "missing"}
+	}
+	r := httptest.NewRequest("GET", "/", nil)
+	r.Header.Set("Accept", "application/json")
+	w := httptest.NewRecorder()
+	Handler(page)(w, r)
+	if w.Code != http.StatusNotFound {
+		t.Errorf("status %d, want 404", w.Code)
+	}
+	if ct := w.Header().Get("Content-Type"); ct != "application/json" {
+		t.Errorf("Content-Type %q, want application/json", ct)
+	}
+	if !strings.Contains(w.Body.String(), `"Title":"missing"`) {
+		t.Errorf("body %s", w.Body.String())
+	}
+}
@@ -0,0 +1,27 @@
+package rendering
+
+import (
+	"io"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// Func is a programmatic renderer.
+// Provide Writer function to render the component with plain Go code.
+type Func struct {
+	Writer func(w io.Writer) error `json:"-"` // Writer function, required
+	Skip   bool                    `json:"-"` // false by default
+}
+
+func (f *Func) RenderSkip() bool {
+	return f.Skip
+}
+
+func (f *Func) Render(state component.State, w io.Writer) error {
+	// Ensure writer is provided
+	if f.Writer == nil {
+		panic("func renderer requires writer function")
+	}
+	// Render
+	return f.Writer(w)
+}
@@ -14,3 +14,19 @@ type Renderer interface {
 	// Render component into io.Writer.
 	Render(state component.State, out io.Writer) error
 }
+
+// Streamer defines an optional streaming requirement for rendering implementations.
+// Streaming renderer flushes the page shell immediately,
+// rendering placeholders for not resolved futures,
+// and streams each component markup as it resolves.
+type Streamer interface {
+	// Define if rendering must to be streamed.
+	RenderStream() bool
+}
+
+// ContentTyper defines an optional content type requirement for rendering implementations.
+// Handler sets Content-Type header with provided value, unless it's already set.
+type ContentTyper interface {
+	// Define a content type of rendering output.
+	RenderContentType() string
+}
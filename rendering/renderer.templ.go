@@ -0,0 +1,39 @@
+package rendering
+
+import (
+	"context"
+	"io"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// TemplComponent is a generated component, compatible with templ (https://templ.guide).
+// Declared here to avoid templ dependency.
+type TemplComponent interface {
+	Render(ctx context.Context, w io.Writer) error
+}
+
+// Templ is a templ-style generated components renderer.
+// Provide Component, generated with the state data.
+type Templ struct {
+	Component TemplComponent `json:"-"` // Generated component, required
+	Skip      bool           `json:"-"` // false by default
+}
+
+func (t *Templ) RenderSkip() bool {
+	return t.Skip
+}
+
+func (t *Templ) Render(state component.State, w io.Writer) error {
+	// Ensure component is provided
+	if t.Component == nil {
+		panic("templ renderer requires component")
+	}
+	// Resolve request context
+	rctx := context.Background()
+	if ctx := component.ContextOf(state); ctx != nil && ctx.Request != nil {
+		rctx = ctx.Request.Context()
+	}
+	// Render
+	return t.Component.Render(rctx, w)
+}
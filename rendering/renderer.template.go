@@ -1,11 +1,13 @@
 package rendering
 
 import (
+	"bytes"
 	"embed"
 	"html/template"
 	"io"
 
 	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/htmx"
 )
 
 // Global template configuration defaults.
@@ -21,18 +23,37 @@ var (
 // or provide template building parameters (Name, Glob, etc.).
 type Template struct {
 	Raw  *template.Template `json:"-"` // Raw template will be used instead if provided
-	Name string             // Resolved from component name by default
+	Name string             // Resolved from component (registered) name by default
 	Skip bool               `json:"-"` // false by default
 
+	Stream  bool     `json:"-"` // Stream page with deferred components, false by default
+	Buffer  bool     `json:"-"` // Buffer output until rendering succeeded, false by default (HANDLER_BUFFERED enables it for non-streamed components)
+	Layouts []string `json:"-"` // Layout template names chain, from inner to outer (empty by default)
+
 	Glob    string           `json:"-"` // *.html by default
 	EmbedFS *embed.FS        `json:"-"` // nil by default
 	FuncMap template.FuncMap `json:"-"` // render.FuncMap by default
 }
 
+// Layout is a data, passed into layout templates.
+// Use Content to insert inner template output into the layout.
+type Layout struct {
+	State   component.State // Component state
+	Content template.HTML   // Inner template output
+}
+
 func (t *Template) RenderSkip() bool {
 	return t.Skip
 }
 
+func (t *Template) RenderStream() bool {
+	return t.Stream
+}
+
+func (t *Template) RenderBuffer() bool {
+	return t.Buffer
+}
+
 func (t *Template) Render(state component.State, w io.Writer) error {
 	// Defaults
 	if t.Name == "" {
@@ -51,6 +72,7 @@ func (t *Template) Render(state component.State, w io.Writer) error {
 		tmpl = template.New(t.Name)
 		// Functions
 		tmpl = tmpl.Funcs(t.FuncMap)
+		tmpl = tmpl.Funcs(template.FuncMap{"include": include(tmpl)})
 		// Parse
 		if t.EmbedFS != nil {
 			// Parse embedded
@@ -63,6 +85,45 @@ func (t *Template) Render(state component.State, w io.Writer) error {
 			tmpl = template.Must(tmpl.ParseGlob(t.Glob))
 		}
 	}
-	// Render
-	return tmpl.Execute(w, state)
+	// Render without layouts,
+	// if there are no layouts or partial content is requested
+	if len(t.Layouts) == 0 || t.partial(state) {
+		return tmpl.Execute(w, state)
+	}
+	// Render inner content
+	content := &bytes.Buffer{}
+	if err := tmpl.Execute(content, state); err != nil {
+		return err
+	}
+	// Slot content into layouts, from inner to outer
+	for _, layout := range t.Layouts {
+		out := &bytes.Buffer{}
+		if err := tmpl.ExecuteTemplate(out, layout, Layout{
+			State:   state,
+			Content: template.HTML(content.String()),
+		}); err != nil {
+			return err
+		}
+		content = out
+	}
+	// Write
+	_, err := content.WriteTo(w)
+	return err
+}
+
+// partial checks if the state is rendered for htmx partial request.
+func (t *Template) partial(state component.State) bool {
+	ctx := component.ContextOf(state)
+	return ctx != nil && ctx.Request != nil && htmx.IsPartial(ctx.Request)
+}
+
+// include builds a template-bound `include` function.
+func include(tmpl *template.Template) func(name string, data any) (template.HTML, error) {
+	return func(name string, data any) (template.HTML, error) {
+		out := &bytes.Buffer{}
+		if err := tmpl.ExecuteTemplate(out, name, data); err != nil {
+			return "", err
+		}
+		return template.HTML(out.String()), nil
+	}
 }
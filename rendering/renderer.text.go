@@ -0,0 +1,83 @@
+package rendering
+
+import (
+	"embed"
+	"io"
+	"text/template"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/zen/v3/mapx"
+)
+
+// Global text template configuration defaults.
+// Embedded filesystem is shared with html templates (TEMPLATE_EMBEDFS).
+var (
+	TEXT_GLOB                          = "*.txt"
+	TEXT_FUNCMAP      template.FuncMap = mapx.Merge(template.FuncMap(FuncMapAll)) // Copy, so it's extended independently
+	TEXT_CONTENT_TYPE                  = "text/plain; charset=utf-8"
+)
+
+// Text is a text/template renderer, suitable for non-HTML output
+// (plain text, XML feeds, sitemaps, etc.).
+// Use Raw to provide handmade template,
+// or provide template building parameters (Name, Glob, etc.).
+type Text struct {
+	Raw         *template.Template `json:"-"` // Raw template will be used instead if provided
+	Name        string             // Resolved from component (registered) name by default
+	Skip        bool               `json:"-"` // false by default
+	ContentType string             `json:"-"` // text/plain by default
+
+	Glob    string           `json:"-"` // *.txt by default
+	EmbedFS *embed.FS        `json:"-"` // nil by default
+	FuncMap template.FuncMap `json:"-"` // TEXT_FUNCMAP by default
+}
+
+func (t *Text) RenderSkip() bool {
+	return t.Skip
+}
+
+func (t *Text) RenderContentType() string {
+	if t.ContentType == "" {
+		return TEXT_CONTENT_TYPE
+	}
+	return t.ContentType
+}
+
+func (t *Text) Render(state component.State, w io.Writer) error {
+	// Defaults
+	if t.Name == "" {
+		t.Name = state.GetName()
+	}
+	if t.Glob == "" {
+		t.Glob = TEXT_GLOB
+	}
+	if t.FuncMap == nil {
+		t.FuncMap = TEXT_FUNCMAP
+	}
+	// Define template
+	tmpl := t.Raw
+	if tmpl == nil {
+		// Base
+		tmpl = template.New(t.Name)
+		// Functions
+		tmpl = tmpl.Funcs(t.FuncMap)
+		// Parse
+		if t.EmbedFS != nil {
+			// Parse embedded
+			tmpl = template.Must(tmpl.ParseFS(t.EmbedFS, t.Glob))
+		} else if TEMPLATE_EMBEDFS != nil {
+			// Parse embedded
+			tmpl = template.Must(tmpl.ParseFS(TEMPLATE_EMBEDFS, t.Glob))
+		} else {
+			// Parse from disk
+			tmpl = template.Must(tmpl.ParseGlob(t.Glob))
+		}
+	}
+	// Render raw template as is
+	if t.Raw != nil {
+		return tmpl.Execute(w, state)
+	}
+	// Render by name, text/template doesn't bind
+	// the base template to the parsed definition with the same name
+	return tmpl.ExecuteTemplate(w, t.Name, state)
+}
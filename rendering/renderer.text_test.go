@@ -0,0 +1,47 @@
+package rendering
+
+import (
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type textState struct {
+	component.Disposable
+	Text
+
+	Title string
+}
+
+func TestText(t *testing.T) {
+	// Write templates
+	dir := t.TempDir()
+	source := `{{ define "Feed" }}{{ .Title }} & {{ shout .Title }}{{ end }}`
+	if err := os.WriteFile(filepath.Join(dir, "feed.txt"), []byte(source), 0644); err != nil {
+		t.Fatal(err)
+	}
+	// Extend text functions only
+	defer delete(TEXT_FUNCMAP, "shout")
+	TEXT_FUNCMAP["shout"] = func(s string) string { return s + "!" }
+	if _, ok := FuncMapAll["shout"]; ok {
+		t.Error("TEXT_FUNCMAP shares the map with FuncMapAll")
+	}
+	// Render
+	page := func(ctx *component.Context) component.State {
+		state := &textState{Title: "<News>"}
+		state.Text.Name = "Feed"
+		state.Glob = filepath.Join(dir, "*.txt")
+		return state
+	}
+	w := httptest.NewRecorder()
+	Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+	if ct := w.Header().Get("Content-Type"); ct != TEXT_CONTENT_TYPE {
+		t.Errorf("Content-Type %q, want %q", ct, TEXT_CONTENT_TYPE)
+	}
+	if body, want := w.Body.String(), "<News> & <News>!"; body != want {
+		t.Errorf("body %q, want %q", body, want)
+	}
+}
@@ -0,0 +1,39 @@
+package rendering
+
+import (
+	"html/template"
+)
+
+// Slotter defines requirements for components, accepting markup from the parent.
+type Slotter interface {
+	// SetSlot fills a named slot with content.
+	SetSlot(name string, content template.HTML)
+	// GetSlot returns a named slot content.
+	GetSlot(name string) template.HTML
+}
+
+// Slots implements named slots for container components.
+// Nest it into the component state to allow the parent
+// to pass markup into the component (f.e. card body or modal content).
+// Slots are not a part of marshaled state.
+type Slots struct {
+	slots map[string]template.HTML
+}
+
+// SetSlot fills a named slot with content.
+func (s *Slots) SetSlot(name string, content template.HTML) {
+	if s.slots == nil {
+		s.slots = map[string]template.HTML{}
+	}
+	s.slots[name] = content
+}
+
+// GetSlot returns a named slot content.
+// Returns empty content if the slot wasn't filled.
+func (s *Slots) GetSlot(name string) template.HTML {
+	return s.slots[name]
+}
+
+// Fill is a set of named slots content,
+// which is passed to the component with `render` function.
+type Fill map[string]template.HTML
@@ -0,0 +1,61 @@
+package rendering
+
+import (
+	"html/template"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type slotsCard struct {
+	component.Disposable
+	Template
+	Slots
+}
+
+type slotsPage struct {
+	component.Disposable
+	Template
+
+	Card component.Future
+	Lazy component.Future
+}
+
+func TestSlotsPlaceholders(t *testing.T) {
+	cardTmpl := template.Must(template.New("card").Funcs(FuncMap).Parse(
+		`<div class="card">{{ slot . "body" }}</div>`,
+	))
+	pageTmpl := template.Must(template.New("page").Funcs(FuncMap).Parse(
+		`<main>{{ render .Card (fill "body" "Hello") }}{{ render .Lazy (fill "body" "Lazy") }}</main>`,
+	))
+	// Slow card, deferred by the stream
+	card := func(ctx *component.Context) component.State {
+		time.Sleep(10 * time.Millisecond)
+		state := &slotsCard{}
+		state.Template.Raw = cardTmpl
+		return state
+	}
+	// Page
+	page := func(ctx *component.Context) component.State {
+		state := &slotsPage{}
+		state.Template.Raw = pageTmpl
+		state.Template.Stream = true
+		state.Card = component.Use(ctx, card)
+		state.Lazy = component.UseLazy(card, component.Lazy{Endpoint: "/card"})
+		return state
+	}
+	// Render
+	w := httptest.NewRecorder()
+	Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+	// Check
+	body := w.Body.String()
+	if !strings.Contains(body, `<div class="card">Hello</div>`) {
+		t.Errorf("deferred card is not filled: %s", body)
+	}
+	if !strings.Contains(body, `hx-get="/card"`) {
+		t.Errorf("lazy card is not rendered: %s", body)
+	}
+}
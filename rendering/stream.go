@@ -0,0 +1,149 @@
+package rendering
+
+import (
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// streamScript swaps rendered chunks into their placeholders.
+// Chunks may arrive before their placeholders (nested components),
+// so swapping is repeated until there is no progress.
+const streamScript = `<script>function kyotoSwap(){var p=true;while(p){p=false;document.querySelectorAll("template[data-kyoto-chunk]").forEach(function(t){var e=document.getElementById("kyoto-pending-"+t.dataset.kyotoChunk);if(!e)return;var n=e.parentNode;e.replaceWith(t.content);t.remove();if(window.htmx)htmx.process(n);p=true})}}</script>`
+
+// chunk is a rendered deferred component.
+type chunk struct {
+	id   string
+	html template.HTML
+	err  error
+}
+
+// stream is a component.Stream implementation,
+// which renders deferred futures in the background.
+type stream struct {
+	mu      sync.Mutex
+	wg      sync.WaitGroup
+	counter int
+	chunks  chan chunk
+	// Renders in progress (shell and chunks).
+	// Deferred components may be prepared by the render,
+	// which placed them, so they wait for it.
+	active map[chan struct{}]struct{}
+}
+
+// Defer resolves and renders the future in the background.
+func (s *stream) Defer(f component.Future) component.State {
+	// Generate identifier and collect renders in progress
+	s.mu.Lock()
+	s.counter++
+	id := strconv.Itoa(s.counter)
+	renders := make([]chan struct{}, 0, len(s.active))
+	for done := range s.active {
+		renders = append(renders, done)
+	}
+	s.mu.Unlock()
+	// Resolve and render in the background
+	p := &component.Pending{ID: id}
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+		s.chunks <- s.resolve(p, f, renders)
+	}()
+	// Return placeholder
+	return p
+}
+
+// begin registers a render in progress.
+// Returned function marks it as done.
+func (s *stream) begin() func() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	done := make(chan struct{})
+	s.active[done] = struct{}{}
+	return func() {
+		s.mu.Lock()
+		delete(s.active, done)
+		s.mu.Unlock()
+		close(done)
+	}
+}
+
+// resolve awaits and renders the future into chunk.
+// Rendering waits for the renders, which may prepare the placeholder.
+// Panics are recovered into chunk error,
+// to be raised in the handler goroutine.
+func (s *stream) resolve(p *component.Pending, f component.Future, renders []chan struct{}) (c chunk) {
+	c.id = p.ID
+	defer func() {
+		if r := recover(); r != nil {
+			c.err = fmt.Errorf("%v", r)
+		}
+	}()
+	// Resolve and prepare
+	state := f()
+	for _, done := range renders {
+		<-done
+	}
+	p.Resolve(state)
+	// Render
+	defer s.begin()()
+	c.html, c.err = render(state)
+	return c
+}
+
+// renderStream renders the page shell immediately
+// and streams deferred components as they resolve.
+func renderStream(ctx *component.Context, state component.State) {
+	// Initialize stream
+	s := &stream{chunks: make(chan chunk), active: map[chan struct{}]struct{}{}}
+	// Keep stream open until the shell is rendered
+	s.wg.Add(1)
+	go func() {
+		s.wg.Wait()
+		close(s.chunks)
+	}()
+	// Provide context with stream,
+	// registering the shell render first
+	done := s.begin()
+	ctx.SetStream(s)
+	// Render shell
+	err := func() error {
+		defer s.wg.Done()
+		defer done()
+		return state.(Renderer).Render(state, ctx.ResponseWriter)
+	}()
+	if err != nil {
+		go drain(s.chunks)
+		panic(err)
+	}
+	// Flush shell and swap script
+	fmt.Fprint(ctx.ResponseWriter, streamScript)
+	flush(ctx.ResponseWriter)
+	// Stream chunks
+	for c := range s.chunks {
+		if c.err != nil {
+			go drain(s.chunks)
+			panic(c.err)
+		}
+		fmt.Fprintf(ctx.ResponseWriter, `<template data-kyoto-chunk="%s">%s</template><script>kyotoSwap()</script>`, c.id, c.html)
+		flush(ctx.ResponseWriter)
+	}
+}
+
+// drain discards remaining chunks,
+// so background goroutines are not blocked forever.
+func drain(chunks chan chunk) {
+	for range chunks {
+	}
+}
+
+// flush flushes response writer, if supported.
+func flush(w http.ResponseWriter) {
+	if f, ok := w.(http.Flusher); ok {
+		f.Flush()
+	}
+}
@@ -0,0 +1,163 @@
+package rendering
+
+import (
+	"html/template"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+type streamPage struct {
+	component.Disposable
+	Template
+
+	Child component.Future
+}
+
+type streamLeaf struct {
+	component.Disposable
+	Func
+}
+
+func TestStreamNested(t *testing.T) {
+	// Leaf component, resolved after the shell is rendered
+	leaf := func(ctx *component.Context) component.State {
+		time.Sleep(10 * time.Millisecond)
+		state := &streamLeaf{}
+		state.Writer = func(w io.Writer) error {
+			_, err := io.WriteString(w, "leaf")
+			return err
+		}
+		return state
+	}
+	// Middle component, awaiting leaf while the page is being rendered
+	middle := func(ctx *component.Context) component.State {
+		child := component.Use(ctx, leaf)
+		time.Sleep(time.Millisecond)
+		resolved := child()
+		state := &streamLeaf{}
+		state.Writer = func(w io.Writer) error {
+			html, err := render(resolved)
+			io.WriteString(w, string(html))
+			return err
+		}
+		return state
+	}
+	// Page
+	page := func(ctx *component.Context) component.State {
+		state := &streamPage{}
+		state.Template.Raw = template.Must(template.New("page").Funcs(FuncMap).Parse(`<div>{{ render .Child }}</div>`))
+		state.Template.Stream = true
+		state.Child = component.Use(ctx, middle)
+		return state
+	}
+	// Render
+	w := httptest.NewRecorder()
+	Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+	// Check
+	body := w.Body.String()
+	if !strings.Contains(body, "leaf") {
+		t.Errorf("nested component is not rendered: %s", body)
+	}
+	if !strings.Contains(body, "kyotoSwap") {
+		t.Errorf("page is not streamed: %s", body)
+	}
+}
+
+type streamTyped struct {
+	streamPage
+
+	Other component.Future
+}
+
+// Kind type-asserts the future state, like component code does.
+func (s *streamTyped) Kind() string {
+	if _, ok := s.Other().(*streamLeaf); !ok {
+		return "pending"
+	}
+	return "resolved"
+}
+
+func TestStreamResolvedGetters(t *testing.T) {
+	// Slow component
+	slow := func(ctx *component.Context) component.State {
+		time.Sleep(10 * time.Millisecond)
+		state := &streamLeaf{}
+		state.Writer = func(w io.Writer) error {
+			_, err := io.WriteString(w, "slow")
+			return err
+		}
+		return state
+	}
+	page := func(ctx *component.Context) component.State {
+		state := &streamTyped{}
+		state.Template.Raw = template.Must(template.New("page").Funcs(FuncMap).Parse(`<div>{{ render .Child }}</div><p>{{ .Kind }}</p>`))
+		state.Template.Stream = true
+		state.Child = component.Use(ctx, slow)
+		state.Other = component.Use(ctx, slow)
+		// Never rendered future, resolved after the stream is closed
+		component.Use(ctx, func(ctx *component.Context) component.State {
+			time.Sleep(30 * time.Millisecond)
+			return slow(ctx)
+		})
+		return state
+	}
+	w := httptest.NewRecorder()
+	Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+	body := w.Body.String()
+	if !strings.Contains(body, "<p>resolved</p>") {
+		t.Errorf("getter doesn't return resolved state: %s", body)
+	}
+	if !strings.Contains(body, `<template data-kyoto-chunk="1">slow</template>`) {
+		t.Errorf("component is not streamed: %s", body)
+	}
+	// Let the never rendered future resolve
+	time.Sleep(40 * time.Millisecond)
+}
+
+// flushRecorder records the body at the first flush.
+type flushRecorder struct {
+	*httptest.ResponseRecorder
+	flushed string
+}
+
+func (r *flushRecorder) Flush() {
+	if r.flushed == "" {
+		r.flushed = r.Body.String()
+	}
+	r.ResponseRecorder.Flush()
+}
+
+func TestStreamHead(t *testing.T) {
+	slow := func(ctx *component.Context) component.State {
+		time.Sleep(20 * time.Millisecond)
+		ctx.Head.Title("Slow")
+		state := &streamLeaf{}
+		state.Writer = func(w io.Writer) error {
+			_, err := io.WriteString(w, "slow")
+			return err
+		}
+		return state
+	}
+	page := func(ctx *component.Context) component.State {
+		ctx.Head.Title("Page")
+		state := &streamPage{}
+		state.Template.Raw = template.Must(template.New("page").Funcs(FuncMap).Parse(`<head>{{ head . }}</head><div>{{ render .Child }}</div>`))
+		state.Template.Stream = true
+		state.Child = component.Use(ctx, slow)
+		return state
+	}
+	w := &flushRecorder{ResponseRecorder: httptest.NewRecorder()}
+	Handler(page)(w, httptest.NewRequest("GET", "/", nil))
+	// Shell is flushed before the slow component is resolved
+	if !strings.Contains(w.flushed, "<title>Page</title>") {
+		t.Errorf("shell head is not flushed: %q", w.flushed)
+	}
+	if strings.Contains(w.flushed, "<template data-kyoto-chunk") {
+		t.Errorf("shell waited for the deferred component: %q", w.flushed)
+	}
+}
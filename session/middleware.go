@@ -0,0 +1,177 @@
+package session
+
+import (
+	"net/http"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+// Middleware builds a rendering handler middleware,
+// which loads the session from the store and exposes it within the context (check Of).
+// Modified session is saved right before the response headers are written.
+// Empty new sessions are not saved, so clients don't get a cookie until it's needed.
+//
+// Changes, made after the response was started (f.e. by streamed or awaited components),
+// are saved once the handler and its futures are done, but the cookie can't be updated anymore.
+// So these changes reach server-side stores only. Cookie store changes,
+// new sessions and rotations made after the first write are lost,
+// modify the session before rendering (f.e. in the page component function) to avoid this.
+//
+//	rendering.HANDLER_MIDDLEWARES = append(rendering.HANDLER_MIDDLEWARES, session.Middleware(session.NewMemory()))
+func Middleware(store Store) rendering.Middleware {
+	return func(ctx *component.Context, next func()) {
+		// Load session
+		s := load(ctx.Request, store)
+		ctx.Store.Set(contextKey, s)
+		// Save session before writing the response
+		w := &writer{ResponseWriter: ctx.ResponseWriter}
+		w.commit = func() { save(w.ResponseWriter, store, s) }
+		ctx.ResponseWriter = w
+		next()
+		ctx.ResponseWriter = w.ResponseWriter
+		// Await futures, which may still modify the session
+		ctx.Wait()
+		// Save session, if nothing was written,
+		// or save changes made after the response was started
+		if w.committed {
+			update(store, s)
+			return
+		}
+		w.persist()
+	}
+}
+
+// load loads a session from the request cookie,
+// or creates a new one if it's missing or invalid.
+func load(r *http.Request, store Store) *Session {
+	cookie, err := r.Cookie(SESSION_COOKIE)
+	if err != nil {
+		return New()
+	}
+	s, err := store.Load(cookie.Value)
+	if err != nil || s == nil {
+		return New()
+	}
+	return s
+}
+
+// save saves a session and writes the cookie, if needed.
+func save(w http.ResponseWriter, store Store, s *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Delete destroyed session, including the rotated one
+	if s.destroyed {
+		for _, id := range []string{s.ID, s.previous} {
+			if id == "" {
+				continue
+			}
+			if err := store.Delete(id); err != nil {
+				panic(err)
+			}
+		}
+		if !s.fresh {
+			http.SetCookie(w, cookie("", time.Unix(0, 0), -1))
+		}
+		return
+	}
+	// Skip unmodified and empty new sessions
+	if !s.modified || (s.fresh && len(s.Values) == 0) {
+		return
+	}
+	// Delete rotated session
+	if s.previous != "" {
+		if err := store.Delete(s.previous); err != nil {
+			panic(err)
+		}
+	}
+	// Save
+	s.Expires = time.Now().Add(SESSION_MAX_AGE)
+	value, err := store.Save(s)
+	if err != nil {
+		panic(err)
+	}
+	http.SetCookie(w, cookie(value, s.Expires, int(SESSION_MAX_AGE.Seconds())))
+	// Track further changes
+	s.fresh, s.modified, s.previous = false, false, ""
+}
+
+// update saves session changes, made after the cookie was written.
+// Client keeps the cookie it already has, so new sessions are not saved
+// and rotated sessions are saved under the previous ID.
+func update(store Store, s *Session) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	// Delete destroyed session, including the rotated one
+	if s.destroyed {
+		for _, id := range []string{s.ID, s.previous} {
+			if id == "" {
+				continue
+			}
+			if err := store.Delete(id); err != nil {
+				panic(err)
+			}
+		}
+		return
+	}
+	// Skip unmodified and new sessions
+	if !s.modified || s.fresh {
+		return
+	}
+	// Restore rotated session ID
+	if s.previous != "" {
+		s.ID, s.previous = s.previous, ""
+	}
+	// Save
+	if _, err := store.Save(s); err != nil {
+		panic(err)
+	}
+	s.modified = false
+}
+
+// cookie builds a session cookie with secure defaults.
+func cookie(value string, expires time.Time, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     SESSION_COOKIE,
+		Value:    value,
+		Path:     SESSION_PATH,
+		Expires:  expires,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   SESSION_SECURE,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
+
+// writer is a response writer,
+// which commits the session before the first write.
+type writer struct {
+	http.ResponseWriter
+	commit    func()
+	committed bool
+}
+
+func (w *writer) persist() {
+	if !w.committed {
+		w.committed = true
+		w.commit()
+	}
+}
+
+func (w *writer) WriteHeader(status int) {
+	w.persist()
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *writer) Write(b []byte) (int, error) {
+	w.persist()
+	return w.ResponseWriter.Write(b)
+}
+
+func (w *writer) Flush() {
+	w.persist()
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
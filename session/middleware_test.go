@@ -0,0 +1,127 @@
+package session
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+)
+
+// serve executes the middleware with provided session cookie.
+func serve(store Store, value string, handler func(ctx *component.Context)) *http.Cookie {
+	r := httptest.NewRequest("GET", "/", nil)
+	if value != "" {
+		r.AddCookie(&http.Cookie{Name: SESSION_COOKIE, Value: value})
+	}
+	w := httptest.NewRecorder()
+	ctx := component.NewContext(w, r)
+	Middleware(store)(ctx, func() { handler(ctx) })
+	for _, c := range w.Result().Cookies() {
+		if c.Name == SESSION_COOKIE {
+			return c
+		}
+	}
+	return nil
+}
+
+func TestMiddleware(t *testing.T) {
+	store := NewMemory()
+	// Empty session doesn't get a cookie
+	if c := serve(store, "", func(ctx *component.Context) { Of(ctx) }); c != nil {
+		t.Errorf("empty session cookie is written: %v", c)
+	}
+	// Modified session is saved before the first write
+	c := serve(store, "", func(ctx *component.Context) {
+		Of(ctx).Set("flash", "hello")
+		ctx.ResponseWriter.Write([]byte("page"))
+	})
+	if c == nil || !c.HttpOnly || c.SameSite != http.SameSiteLaxMode || c.Secure != SESSION_SECURE {
+		t.Fatalf("session cookie %v is not written with secure defaults", c)
+	}
+	// Changes after the first write are saved to server-side store
+	serve(store, c.Value, func(ctx *component.Context) {
+		ctx.ResponseWriter.Write([]byte("page"))
+		var flash string
+		if !Of(ctx).Pop("flash", &flash) || flash != "hello" {
+			t.Errorf("flash is %q", flash)
+		}
+	})
+	serve(store, c.Value, func(ctx *component.Context) {
+		if Of(ctx).Get("flash", new(string)) {
+			t.Error("popped value is still stored")
+		}
+	})
+	// Changes of futures are awaited
+	serve(store, c.Value, func(ctx *component.Context) {
+		ctx.ResponseWriter.Write([]byte("page"))
+		component.Use(ctx, func(ctx *component.Context) component.State {
+			time.Sleep(10 * time.Millisecond)
+			Of(ctx).Set("future", true)
+			return &component.Disposable{}
+		})
+	})
+	serve(store, c.Value, func(ctx *component.Context) {
+		if !Of(ctx).Get("future", new(bool)) {
+			t.Error("future value is not stored")
+		}
+	})
+}
+
+func TestMiddlewareRotate(t *testing.T) {
+	store := NewMemory()
+	c := serve(store, "", func(ctx *component.Context) { Of(ctx).Set("user", 1) })
+	// Rotation issues a new ID and deletes the previous one
+	rotated := serve(store, c.Value, func(ctx *component.Context) { Of(ctx).Rotate() })
+	if rotated == nil || rotated.Value == c.Value {
+		t.Fatalf("rotated cookie is %v", rotated)
+	}
+	if _, err := store.Load(c.Value); err != ErrInvalid {
+		t.Error("previous session is not deleted")
+	}
+	// Late rotation keeps the ID, known by the client
+	serve(store, rotated.Value, func(ctx *component.Context) {
+		ctx.ResponseWriter.Write([]byte("page"))
+		Of(ctx).Rotate()
+		Of(ctx).Set("late", true)
+	})
+	s, err := store.Load(rotated.Value)
+	if err != nil || !s.Get("late", new(bool)) {
+		t.Errorf("late rotated session is not saved under the previous ID (%v)", err)
+	}
+}
+
+func TestMiddlewareDestroy(t *testing.T) {
+	tests := []struct {
+		name   string
+		late   bool
+		rotate bool
+	}{
+		{"before write", false, false},
+		{"after write", true, false},
+		{"rotated before write", false, true},
+		{"rotated after write", true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			store := NewMemory()
+			c := serve(store, "", func(ctx *component.Context) { Of(ctx).Set("user", 1) })
+			expired := serve(store, c.Value, func(ctx *component.Context) {
+				if tt.late {
+					ctx.ResponseWriter.Write([]byte("page"))
+				}
+				if tt.rotate {
+					Of(ctx).Rotate()
+				}
+				Of(ctx).Destroy()
+			})
+			if !tt.late && (expired == nil || expired.MaxAge >= 0) {
+				t.Errorf("cookie %v is not expired", expired)
+			}
+			if _, err := store.Load(c.Value); err != ErrInvalid {
+				t.Error("destroyed session is not deleted")
+			}
+		})
+	}
+}
@@ -0,0 +1,130 @@
+package session
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/zen/v3/errorsx"
+)
+
+// Global session configuration defaults.
+var (
+	SESSION_COOKIE  = "session"           // Session cookie name
+	SESSION_PATH    = "/"                 // Session cookie path
+	SESSION_MAX_AGE = 30 * 24 * time.Hour // Session lifetime, extended on each modification
+	SESSION_SECURE  = true                // Send session cookie over HTTPS only
+)
+
+// contextKey is a context store key of the session.
+const contextKey = "kyoto.session"
+
+// Session holds values of the client session.
+// Values are JSON-encoded, so any serializable value can be stored.
+// It's safe for concurrent use (nested components are built concurrently).
+type Session struct {
+	ID      string
+	Values  map[string]json.RawMessage
+	Expires time.Time
+
+	mu        sync.Mutex
+	fresh     bool   // Session wasn't loaded from the store
+	modified  bool   // Session has to be saved
+	destroyed bool   // Session has to be deleted
+	previous  string // Session ID before rotation
+}
+
+// New creates a new empty session with random ID.
+func New() *Session {
+	return &Session{
+		ID:     id(),
+		Values: map[string]json.RawMessage{},
+		fresh:  true,
+	}
+}
+
+// Of returns a session of the context.
+// Panics if session middleware is not registered.
+func Of(ctx *component.Context) *Session {
+	if s, ok := ctx.Store.Get(contextKey).(*Session); ok {
+		return s
+	}
+	panic("session middleware is not registered")
+}
+
+// Get decodes a value by key into dst.
+// Returns false if value is not found or can't be decoded.
+func (s *Session) Get(key string, dst any) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	value, ok := s.Values[key]
+	return ok && json.Unmarshal(value, dst) == nil
+}
+
+// Set stores a value by key.
+func (s *Session) Set(key string, value any) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Values[key] = errorsx.Must(json.Marshal(value))
+	s.modified = true
+}
+
+// Delete removes a value by key.
+func (s *Session) Delete(key string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.Values[key]; ok {
+		delete(s.Values, key)
+		s.modified = true
+	}
+}
+
+// Pop decodes a value by key into dst and removes it.
+// Useful for flash messages, which have to be shown once.
+//
+//	var flash string
+//	if session.Of(ctx).Pop("flash", &flash) { ... }
+func (s *Session) Pop(key string, dst any) bool {
+	ok := s.Get(key, dst)
+	s.Delete(key)
+	return ok
+}
+
+// Clear removes all values.
+func (s *Session) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Values = map[string]json.RawMessage{}
+	s.modified = true
+}
+
+// Rotate assigns a new session ID, keeping values.
+// Rotate the session on privilege changes (f.e. sign in)
+// to prevent session fixation.
+func (s *Session) Rotate() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.previous == "" && !s.fresh {
+		s.previous = s.ID
+	}
+	s.ID = id()
+	s.modified = true
+}
+
+// Destroy removes the session from the store and the client (f.e. on sign out).
+func (s *Session) Destroy() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.Values = map[string]json.RawMessage{}
+	s.destroyed = true
+}
+
+// id generates a random session ID.
+func id() string {
+	b := make([]byte, 32)
+	errorsx.Must(rand.Read(b))
+	return base64.RawURLEncoding.EncodeToString(b)
+}
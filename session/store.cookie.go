@@ -0,0 +1,100 @@
+package session
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// Cookie is a client-side session store.
+// Session data is encrypted and authenticated (AES-GCM) and stored in the cookie itself,
+// so the session size is limited with 4KB.
+// Sessions can't be revoked on the server side, deletion only clears the client cookie.
+type Cookie struct {
+	aeads []cipher.AEAD
+}
+
+// NewCookie creates a cookie store with provided secrets.
+// The first secret is used for encryption, all of them are used for decryption,
+// so secrets can be rotated without invalidating existing sessions.
+func NewCookie(secrets ...[]byte) *Cookie {
+	// Validate
+	if len(secrets) == 0 {
+		panic("cookie store requires at least one secret")
+	}
+	// Build ciphers, with keys derived from secrets
+	store := &Cookie{}
+	for _, secret := range secrets {
+		key := sha256.Sum256(secret)
+		block, err := aes.NewCipher(key[:])
+		if err != nil {
+			panic(err)
+		}
+		aead, err := cipher.NewGCM(block)
+		if err != nil {
+			panic(err)
+		}
+		store.aeads = append(store.aeads, aead)
+	}
+	return store
+}
+
+// Load decrypts a session from the cookie value.
+func (c *Cookie) Load(value string) (*Session, error) {
+	// Decode
+	data, err := base64.RawURLEncoding.DecodeString(value)
+	if err != nil {
+		return nil, ErrInvalid
+	}
+	// Decrypt with any of the secrets
+	for _, aead := range c.aeads {
+		if len(data) < aead.NonceSize() {
+			continue
+		}
+		plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], nil)
+		if err != nil {
+			continue
+		}
+		// Unmarshal and validate
+		session := &Session{}
+		if err := json.Unmarshal(plain, session); err != nil || session.Values == nil {
+			return nil, ErrInvalid
+		}
+		if time.Now().After(session.Expires) {
+			return nil, ErrInvalid
+		}
+		return session, nil
+	}
+	return nil, ErrInvalid
+}
+
+// Save encrypts a session into the cookie value.
+func (c *Cookie) Save(session *Session) (string, error) {
+	// Marshal
+	plain, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+	// Encrypt with the first secret
+	aead := c.aeads[0]
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	value := base64.RawURLEncoding.EncodeToString(aead.Seal(nonce, nonce, plain, nil))
+	// Validate size
+	if len(value) > 4000 {
+		return "", fmt.Errorf("session is too large for cookie store (%d bytes)", len(value))
+	}
+	return value, nil
+}
+
+// Delete does nothing, cookie sessions can't be revoked on the server side.
+func (c *Cookie) Delete(id string) error {
+	return nil
+}
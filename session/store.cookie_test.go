@@ -0,0 +1,71 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCookie(t *testing.T) {
+	must := func(value string, err error) string {
+		t.Helper()
+		if err != nil {
+			t.Fatal(err)
+		}
+		return value
+	}
+	old, current := NewCookie([]byte("old")), NewCookie([]byte("current"), []byte("old"))
+	// Build values
+	s := New()
+	s.Set("user", 42)
+	s.Expires = time.Now().Add(time.Hour)
+	value := must(current.Save(s))
+	previous := must(old.Save(s))
+	expired := &Session{ID: s.ID, Values: s.Values, Expires: time.Now().Add(-time.Hour)}
+	// Tamper with the last character, changing the authentication tag
+	tampered := []byte(value)
+	if tampered[len(tampered)-1] == 'A' {
+		tampered[len(tampered)-1] = 'B'
+	} else {
+		tampered[len(tampered)-1] = 'A'
+	}
+	tests := []struct {
+		name  string
+		store *Cookie
+		value string
+		valid bool
+	}{
+		{"valid", current, value, true},
+		{"rotated secret", current, previous, true},
+		{"unknown secret", old, value, false},
+		{"tampered", current, string(tampered), false},
+		{"expired", current, must(current.Save(expired)), false},
+		{"malformed", current, "not base64!", false},
+		{"short", current, "AAAA", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loaded, err := tt.store.Load(tt.value)
+			if !tt.valid {
+				if err != ErrInvalid {
+					t.Errorf("error is %v, expected ErrInvalid", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			var user int
+			if loaded.ID != s.ID || !loaded.Get("user", &user) || user != 42 {
+				t.Errorf("loaded session %+v doesn't match", loaded)
+			}
+		})
+	}
+}
+
+func TestCookieSize(t *testing.T) {
+	s := New()
+	s.Set("data", string(make([]byte, 4000)))
+	if _, err := NewCookie([]byte("secret")).Save(s); err == nil {
+		t.Error("oversized session is saved")
+	}
+}
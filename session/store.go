@@ -0,0 +1,18 @@
+package session
+
+import "errors"
+
+// ErrInvalid is returned by the store on invalid or expired session.
+var ErrInvalid = errors.New("invalid session")
+
+// Store is a session persistence interface.
+// Cookie value is a store-specific session representation
+// (f.e. encrypted session data or session ID).
+type Store interface {
+	// Load loads a session by cookie value.
+	Load(value string) (*Session, error)
+	// Save saves a session and returns cookie value.
+	Save(session *Session) (string, error)
+	// Delete deletes a session by ID.
+	Delete(id string) error
+}
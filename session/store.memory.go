@@ -0,0 +1,77 @@
+package session
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+)
+
+// Memory is a server-side in-memory session store.
+// Only session ID is stored in the cookie.
+// Sessions are lost on restart and aren't shared between instances,
+// implement Store over your database for such cases.
+type Memory struct {
+	mu       sync.Mutex
+	sessions map[string][]byte
+	expires  map[string]time.Time
+	swept    time.Time
+}
+
+// NewMemory creates an empty in-memory store.
+func NewMemory() *Memory {
+	return &Memory{
+		sessions: map[string][]byte{},
+		expires:  map[string]time.Time{},
+	}
+}
+
+// Load loads a session by ID.
+func (m *Memory) Load(value string) (*Session, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Lookup
+	data, ok := m.sessions[value]
+	if !ok || time.Now().After(m.expires[value]) {
+		return nil, ErrInvalid
+	}
+	// Unmarshal
+	session := &Session{}
+	if err := json.Unmarshal(data, session); err != nil {
+		return nil, err
+	}
+	return session, nil
+}
+
+// Save stores a session and returns its ID.
+func (m *Memory) Save(session *Session) (string, error) {
+	// Marshal
+	data, err := json.Marshal(session)
+	if err != nil {
+		return "", err
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	// Store
+	m.sessions[session.ID] = data
+	m.expires[session.ID] = session.Expires
+	// Sweep expired sessions, at most once a minute
+	if now := time.Now(); now.Sub(m.swept) > time.Minute {
+		for id, expires := range m.expires {
+			if now.After(expires) {
+				delete(m.sessions, id)
+				delete(m.expires, id)
+			}
+		}
+		m.swept = now
+	}
+	return session.ID, nil
+}
+
+// Delete deletes a session by ID.
+func (m *Memory) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.sessions, id)
+	delete(m.expires, id)
+	return nil
+}
@@ -0,0 +1,51 @@
+package session
+
+import (
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	store := NewMemory()
+	// Save sessions
+	s := New()
+	s.Set("user", 42)
+	s.Expires = time.Now().Add(time.Hour)
+	if id, err := store.Save(s); err != nil || id != s.ID {
+		t.Fatalf("save returned %q (%v), want %q", id, err, s.ID)
+	}
+	expiring := New()
+	expiring.Expires = time.Now().Add(20 * time.Millisecond)
+	store.Save(expiring)
+	// Load
+	loaded, err := store.Load(s.ID)
+	if err != nil || !loaded.Get("user", new(int)) {
+		t.Errorf("saved session is not loaded (%v)", err)
+	}
+	if _, err := store.Load("unknown"); err != ErrInvalid {
+		t.Errorf("unknown session error is %v, want ErrInvalid", err)
+	}
+	// Expired session is not loaded, but kept until the sweep
+	time.Sleep(30 * time.Millisecond)
+	if _, err := store.Load(expiring.ID); err != ErrInvalid {
+		t.Errorf("expired session error is %v, want ErrInvalid", err)
+	}
+	store.Save(s)
+	if _, ok := store.sessions[expiring.ID]; !ok {
+		t.Error("expired session is swept more often than once a minute")
+	}
+	// Sweep
+	store.swept = time.Now().Add(-2 * time.Minute)
+	store.Save(s)
+	if _, ok := store.sessions[expiring.ID]; ok {
+		t.Error("expired session is not swept")
+	}
+	if _, err := store.Load(s.ID); err != nil {
+		t.Errorf("valid session is swept (%v)", err)
+	}
+	// Delete
+	store.Delete(s.ID)
+	if _, err := store.Load(s.ID); err != ErrInvalid {
+		t.Errorf("deleted session error is %v, want ErrInvalid", err)
+	}
+}
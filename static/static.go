@@ -0,0 +1,140 @@
+package static
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+	"go.kyoto.codes/zen/v3/logic"
+)
+
+// Route is a static route definition.
+type Route struct {
+	Path        string                   // Route path (f.e. "/about", "/feed.xml")
+	Paths       func() ([]string, error) // Dynamic paths enumeration (f.e. all blog slugs), overrides Path
+	Component   component.Component      // Route page component
+	Middlewares []rendering.Middleware   // Handler middlewares
+}
+
+// Exporter renders routes through rendering.Handler with synthetic requests
+// and writes resulting HTML files (with copied assets) into output directory.
+type Exporter struct {
+	Output string           // Output directory ("dist" by default)
+	Host   string           // Synthetic requests host ("localhost" by default)
+	Routes []Route          // Routes to export
+	Assets map[string]fs.FS // Assets to copy, by output subdirectory (f.e. "static": os.DirFS("static"))
+}
+
+// Export is a shortcut for exporting routes into output directory.
+func Export(output string, routes ...Route) error {
+	return (&Exporter{Output: output, Routes: routes}).Export()
+}
+
+// output wraps `Output` and resolves with default option.
+func (e *Exporter) output() string {
+	return logic.Or(e.Output, "dist")
+}
+
+// Export renders all routes and copies assets.
+func (e *Exporter) Export() error {
+	// Export routes
+	for _, route := range e.Routes {
+		// Resolve paths
+		paths := []string{route.Path}
+		if route.Paths != nil {
+			var err error
+			if paths, err = route.Paths(); err != nil {
+				return fmt.Errorf("enumerate %s paths: %w", route.Component.GetName(), err)
+			}
+		}
+		// Render each path
+		for _, p := range paths {
+			if err := e.page(route, p); err != nil {
+				return fmt.Errorf("export %s: %w", p, err)
+			}
+		}
+	}
+	// Copy assets
+	for dir, fsys := range e.Assets {
+		if err := e.copy(dir, fsys); err != nil {
+			return fmt.Errorf("copy %s assets: %w", dir, err)
+		}
+	}
+	return nil
+}
+
+// page renders a single path and writes it into the output file.
+func (e *Exporter) page(route Route, p string) (err error) {
+	// Recover handler panics into error
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	// Render with synthetic request
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, p, nil)
+	req.Host = logic.Or(e.Host, "localhost")
+	rendering.Handler(route.Component, route.Middlewares...)(rec, req)
+	// Ensure success
+	if rec.Code < 200 || rec.Code > 299 {
+		return fmt.Errorf("unexpected status code %d", rec.Code)
+	}
+	// Write
+	return write(filepath.Join(e.output(), File(p)), rec.Body)
+}
+
+// copy copies filesystem contents into output subdirectory.
+func (e *Exporter) copy(dir string, fsys fs.FS) error {
+	return fs.WalkDir(fsys, ".", func(p string, d fs.DirEntry, err error) error {
+		// Pass errors and directories
+		if err != nil || d.IsDir() {
+			return err
+		}
+		// Copy file
+		src, err := fsys.Open(p)
+		if err != nil {
+			return err
+		}
+		defer src.Close()
+		return write(filepath.Join(e.output(), dir, filepath.FromSlash(p)), src)
+	})
+}
+
+// File returns an output file path of the route path.
+// Paths without extension are exported as directories with index.html,
+// so they are served with the same URL by static servers.
+func File(p string) string {
+	p = path.Clean("/" + strings.TrimSuffix(strings.SplitN(p, "?", 2)[0], "/"))
+	if path.Ext(p) == "" {
+		p = path.Join(p, "index.html")
+	}
+	return filepath.FromSlash(strings.TrimPrefix(p, "/"))
+}
+
+// write writes reader contents into the file, creating directories if needed.
+func write(name string, r io.Reader) error {
+	// Ensure directory exists
+	if err := os.MkdirAll(filepath.Dir(name), 0755); err != nil {
+		return err
+	}
+	// Create file
+	f, err := os.Create(name)
+	if err != nil {
+		return err
+	}
+	// Write
+	if _, err := io.Copy(f, r); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
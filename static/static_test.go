@@ -0,0 +1,126 @@
+package static
+
+import (
+	"errors"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+
+	"go.kyoto.codes/v3/component"
+	"go.kyoto.codes/v3/rendering"
+)
+
+type testState struct {
+	component.Disposable
+	rendering.Func
+}
+
+// testPage renders the request path.
+func testPage(ctx *component.Context) component.State {
+	state := &testState{}
+	state.Writer = func(w io.Writer) error {
+		_, err := io.WriteString(w, ctx.Request.URL.RequestURI())
+		return err
+	}
+	return state
+}
+
+func TestFile(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/", "index.html"},
+		{"", "index.html"},
+		{"/about", "about/index.html"},
+		{"/about/", "about/index.html"},
+		{"/blog/hello-world", "blog/hello-world/index.html"},
+		{"/blog/post?page=2", "blog/post/index.html"},
+		{"/?page=2", "index.html"},
+		{"/feed.xml", "feed.xml"},
+		{"/a/../b", "b/index.html"},
+		{"/../../etc", "etc/index.html"},
+	}
+	for _, tt := range tests {
+		if got := File(tt.path); got != filepath.FromSlash(tt.want) {
+			t.Errorf("File(%q) = %q, want %q", tt.path, got, tt.want)
+		}
+	}
+}
+
+func TestExport(t *testing.T) {
+	output := t.TempDir()
+	e := &Exporter{
+		Output: output,
+		Routes: []Route{
+			{Path: "/", Component: testPage},
+			{Path: "/feed.xml", Component: testPage},
+			{Paths: func() ([]string, error) { return []string{"/blog/a", "/blog/b?draft=1"}, nil }, Component: testPage},
+		},
+		Assets: map[string]fs.FS{
+			"static": fstest.MapFS{"css/app.css": {Data: []byte("body{}")}},
+		},
+	}
+	if err := e.Export(); err != nil {
+		t.Fatal(err)
+	}
+	files := map[string]string{
+		"index.html":         "/",
+		"feed.xml":           "/feed.xml",
+		"blog/a/index.html":  "/blog/a",
+		"blog/b/index.html":  "/blog/b?draft=1",
+		"static/css/app.css": "body{}",
+	}
+	for name, want := range files {
+		content, err := os.ReadFile(filepath.Join(output, filepath.FromSlash(name)))
+		if err != nil || string(content) != want {
+			t.Errorf("%s is %q (%v), want %q", name, content, err, want)
+		}
+	}
+}
+
+func TestExportErrors(t *testing.T) {
+	// Output, which can't be created
+	blocked := filepath.Join(t.TempDir(), "blocked")
+	if err := os.WriteFile(blocked, nil, 0644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		exporter *Exporter
+		want     string
+	}{
+		{"paths", &Exporter{Routes: []Route{{
+			Paths:     func() ([]string, error) { return nil, errors.New("database is down") },
+			Component: testPage,
+		}}}, "enumerate testPage paths: database is down"},
+		{"status", &Exporter{Routes: []Route{{
+			Path:        "/missing",
+			Component:   testPage,
+			Middlewares: []rendering.Middleware{func(ctx *component.Context, next func()) { ctx.ResponseWriter.WriteHeader(404) }},
+		}}}, "export /missing: unexpected status code 404"},
+		{"panic", &Exporter{Routes: []Route{{
+			Path:      "/broken",
+			Component: func(ctx *component.Context) component.State { panic("broken component") },
+		}}}, "export /broken: broken component"},
+		{"write", &Exporter{Output: blocked, Routes: []Route{{Path: "/", Component: testPage}}}, "export /: "},
+		{"assets", &Exporter{Output: blocked, Assets: map[string]fs.FS{
+			"static": fstest.MapFS{"app.css": {}},
+		}}, "copy static assets: "},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.exporter.Output == "" {
+				tt.exporter.Output = t.TempDir()
+			}
+			err := tt.exporter.Export()
+			if err == nil || !strings.HasPrefix(err.Error(), tt.want) {
+				t.Errorf("got error %v, want %q", err, tt.want)
+			}
+		})
+	}
+}